@@ -0,0 +1,234 @@
+package money
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidBucketSize indicates that a bucket size is zero or negative
+	ErrInvalidBucketSize = errors.New("invalid bucket size")
+	// ErrLengthMismatch indicates that an operation was given slices of
+	// different lengths
+	ErrLengthMismatch = errors.New("length mismatch")
+	// ErrZeroWeight indicates that weights sum up to zero
+	ErrZeroWeight = errors.New("weights sum up to zero")
+	// ErrNoAmounts indicates that an aggregation was given no amounts or
+	// values
+	ErrNoAmounts = errors.New("no amounts")
+	// ErrInvalidQuantile indicates that a quantile is outside of [0, 1]
+	ErrInvalidQuantile = errors.New("invalid quantile")
+	// ErrZeroTotal indicates that amounts sum up to zero
+	ErrZeroTotal = errors.New("amounts sum up to zero")
+)
+
+// Histogram groups amounts into buckets of bucketSize width and counts them.
+// Buckets are keyed by their lower bound, which is written with the precision
+// of bucketSize. All amounts must share the same currency.
+//
+//   e.g. [5.00, 12.50, 19.99, 20.00] USD by 10.00	-> {"0.00": 1, "10.00": 2, "20.00": 1}
+func Histogram(amounts []*Money, bucketSize Decimal) (map[string]int, error) {
+	if bucketSize.Sign() != SignPositive {
+		return nil, ErrInvalidBucketSize
+	}
+
+	buckets := map[string]int{}
+	for _, m := range amounts {
+		if m.Currency != amounts[0].Currency {
+			return nil, ErrCurrencyMismatch
+		}
+
+		// Floor the quotient, so negative amounts fall in the bucket below
+		q, r := m.Amount.quoRem(bucketSize, 0)
+		if r.Sign() == SignNegative {
+			q = q.Sub(one)
+		}
+		buckets[q.Mul(bucketSize).String()]++
+	}
+	return buckets, nil
+}
+
+// WeightedAvg returns the average of values weighted by weights, such as
+// sum(value * weight) / sum(weights), rounded to the currency scale. All values
+// must share the same currency.
+//
+//   e.g. [10.00, 20.00] USD by [1, 3]	-> 17.50 USD
+func WeightedAvg(values []*Money, weights []Decimal) (*Money, error) {
+	if len(values) != len(weights) {
+		return nil, ErrLengthMismatch
+	}
+	if len(values) == 0 {
+		return nil, ErrZeroWeight
+	}
+
+	c := values[0].Currency
+	products := make([]Decimal, len(values))
+	for i, v := range values {
+		if v.Currency != c {
+			return nil, ErrCurrencyMismatch
+		}
+		products[i] = v.Amount.Mul(weights[i])
+	}
+	sum := SumDecimal(weights...)
+	if sum.IsZero() {
+		return nil, ErrZeroWeight
+	}
+	return &Money{
+		Amount:   SumDecimal(products...).divRound(sum, int32(c.Scale())),
+		Currency: c,
+	}, nil
+}
+
+// MaxMoneyStream returns the largest amount received from ch, until ch is
+// closed or ctx is done. Nil amounts are skipped. All amounts must share the
+// currency of the first one, otherwise it stops with an error wrapping
+// ErrCurrencyMismatch which describes the offending amount.
+//
+// It returns ErrNoAmounts when ch is closed before receiving any amount, and
+// the context error when ctx is done first.
+func MaxMoneyStream(ctx context.Context, ch <-chan *Money) (*Money, error) {
+	var max *Money
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case m, ok := <-ch:
+			if !ok {
+				if max == nil {
+					return nil, ErrNoAmounts
+				}
+				return max, nil
+			}
+			if m == nil {
+				continue
+			}
+			if max == nil {
+				max = m
+				continue
+			}
+			if m.Currency != max.Currency {
+				return nil, fmt.Errorf("%w: %s %s", ErrCurrencyMismatch, m.Amount, m.Currency)
+			}
+			if m.Amount.Cmp(max.Amount) > 0 {
+				max = m
+			}
+		}
+	}
+}
+
+// Quantile returns the q-quantile of sorted, which must be in ascending order,
+// interpolating linearly between the two closest ranks. q must be within
+// [0, 1].
+//
+//   e.g. [1, 2, 3, 4] 0.5	-> 2.5
+//   e.g. [1, 2, 3, 4] 0.25	-> 1.75
+func Quantile(sorted []Decimal, q Decimal) (Decimal, error) {
+	if len(sorted) == 0 {
+		return Decimal{}, ErrNoAmounts
+	}
+	if q.Sign() == SignNegative || q.Cmp(one) > 0 {
+		return Decimal{}, ErrInvalidQuantile
+	}
+
+	// Fractional rank h = (n - 1) * q, between sorted[lo] and sorted[lo+1]
+	h := q.Mul(buildDecimal(int64(len(sorted)-1), 0))
+	lo := h.Floor()
+	frac := h.Sub(lo)
+	i := int(lo.IntPart())
+	if frac.IsZero() {
+		return sorted[i], nil
+	}
+	return sorted[i].Add(sorted[i+1].Sub(sorted[i]).Mul(frac)), nil
+}
+
+// PercentOfTotal returns the share of each item in the sum of items, as a
+// percentage rounded to DivisionPrecision digits. All items must share the
+// same currency. The percentages are not adjusted, so they may not sum up to
+// exactly 100.
+//
+//   e.g. [1, 1, 2] USD	-> [25, 25, 50]
+//   e.g. [1, 1, 1] USD	-> [33.3333333333333333, ...]
+func PercentOfTotal(items []*Money) ([]Decimal, error) {
+	amounts := make([]Decimal, len(items))
+	for i, m := range items {
+		if m.Currency != items[0].Currency {
+			return nil, ErrCurrencyMismatch
+		}
+		amounts[i] = m.Amount
+	}
+	total := SumDecimal(amounts...)
+	if total.IsZero() {
+		return nil, ErrZeroTotal
+	}
+
+	percents := make([]Decimal, len(items))
+	for i, a := range amounts {
+		percents[i] = a.Mul(hundred).divRound(total, int32(GetConfig().DivisionPrecision))
+	}
+	return percents, nil
+}
+
+// Net returns the net position of a ledger, i.e. the sum of its entries, where
+// credits are positive and debits negative. All entries must share the same
+// currency, and there must be at least one entry.
+//
+//   e.g. [100.00, -30.50, -20.00] CHF	-> 49.50 CHF
+func Net(entries []*Money) (*Money, error) {
+	if len(entries) == 0 {
+		return nil, ErrNoAmounts
+	}
+
+	c := entries[0].Currency
+	amounts := make([]Decimal, len(entries))
+	for i, e := range entries {
+		if e.Currency != c {
+			return nil, ErrCurrencyMismatch
+		}
+		amounts[i] = e.Amount
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return NewMoney(SumDecimal(amounts...), c), nil
+}
+
+// NetByCurrency is like Net, but nets mixed entries per currency. It returns an
+// error only when an entry has an invalid currency.
+//
+//   e.g. [100.00 CHF, -30.00 EUR, -20.00 CHF]	-> {CHF: 80.00, EUR: -30.00}
+func NetByCurrency(entries []*Money) (map[Currency]*Money, error) {
+	amounts := map[Currency][]Decimal{}
+	for _, e := range entries {
+		amounts[e.Currency] = append(amounts[e.Currency], e.Amount)
+	}
+
+	nets := make(map[Currency]*Money, len(amounts))
+	for c, a := range amounts {
+		if err := c.Validate(); err != nil {
+			return nil, err
+		}
+		nets[c] = NewMoney(SumDecimal(a...), c)
+	}
+	return nets, nil
+}
+
+// GroupByCurrency returns the sum of items per currency. Nil items are
+// skipped. Unlike NetByCurrency, currencies are not validated.
+//
+//   e.g. [10.00 CHF, 5.00 EUR, 2.50 CHF]	-> {CHF: 12.50, EUR: 5.00}
+func GroupByCurrency(items []*Money) map[Currency]*Money {
+	amounts := map[Currency][]Decimal{}
+	for _, m := range items {
+		if m == nil {
+			continue
+		}
+		amounts[m.Currency] = append(amounts[m.Currency], m.Amount)
+	}
+
+	totals := make(map[Currency]*Money, len(amounts))
+	for c, a := range amounts {
+		totals[c] = NewMoney(SumDecimal(a...), c)
+	}
+	return totals
+}
@@ -0,0 +1,487 @@
+package money_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/deixis/money"
+)
+
+func TestHistogram(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		amounts []*money.Money
+		size    string
+		expect  map[string]int
+		err     error
+	}{
+		{
+			amounts: []*money.Money{
+				money.MustParse("5.00", "USD"),
+				money.MustParse("0", "USD"),
+				money.MustParse("12.50", "USD"),
+				money.MustParse("19.99", "USD"),
+				money.MustParse("20.00", "USD"),
+				money.MustParse("42", "USD"),
+				money.MustParse("-0.01", "USD"),
+				money.MustParse("-10", "USD"),
+			},
+			size: "10.00",
+			expect: map[string]int{
+				"-10.00": 2,
+				"0.00":   2,
+				"10.00":  2,
+				"20.00":  1,
+				"40.00":  1,
+			},
+		},
+		{
+			amounts: []*money.Money{},
+			size:    "10.00",
+			expect:  map[string]int{},
+		},
+		{
+			amounts: []*money.Money{
+				money.MustParse("5.00", "USD"),
+				money.MustParse("5.00", "EUR"),
+			},
+			size: "10.00",
+			err:  money.ErrCurrencyMismatch,
+		},
+		{
+			amounts: []*money.Money{money.MustParse("5.00", "USD")},
+			size:    "0",
+			err:     money.ErrInvalidBucketSize,
+		},
+	}
+
+	for i, test := range table {
+		res, err := money.Histogram(test.amounts, money.MustParseDecimal(test.size))
+		if test.err != err {
+			t.Errorf("#%d - expect error %s, but got %s", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !reflect.DeepEqual(test.expect, res) {
+			t.Errorf("#%d - expect %v, but got %v", i, test.expect, res)
+		}
+	}
+}
+
+func TestWeightedAvg(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		values  []*money.Money
+		weights []string
+		expect  *money.Money
+		err     error
+	}{
+		{
+			values: []*money.Money{
+				money.MustParse("10.00", "USD"),
+				money.MustParse("20.00", "USD"),
+				money.MustParse("40.00", "USD"),
+			},
+			weights: []string{"2", "2", "2"},
+			expect:  money.MustParse("23.33", "USD"),
+		},
+		{
+			values: []*money.Money{
+				money.MustParse("10.00", "USD"),
+				money.MustParse("20.00", "USD"),
+			},
+			weights: []string{"1", "3"},
+			expect:  money.MustParse("17.50", "USD"),
+		},
+		{
+			values: []*money.Money{
+				money.MustParse("101.25", "USD"),
+				money.MustParse("101.30", "USD"),
+				money.MustParse("101.10", "USD"),
+			},
+			weights: []string{"150", "50.5", "300"},
+			expect:  money.MustParse("101.17", "USD"),
+		},
+		{
+			values: []*money.Money{
+				money.MustParse("-10", "JPY"),
+				money.MustParse("-20", "JPY"),
+			},
+			weights: []string{"1", "2"},
+			expect:  money.MustParse("-17", "JPY"),
+		},
+		{
+			values: []*money.Money{
+				money.MustParse("10.00", "USD"),
+			},
+			weights: []string{"1", "2"},
+			err:     money.ErrLengthMismatch,
+		},
+		{
+			values: []*money.Money{
+				money.MustParse("10.00", "USD"),
+				money.MustParse("10.00", "EUR"),
+			},
+			weights: []string{"1", "2"},
+			err:     money.ErrCurrencyMismatch,
+		},
+		{
+			values: []*money.Money{
+				money.MustParse("10.00", "USD"),
+				money.MustParse("10.00", "USD"),
+			},
+			weights: []string{"1", "-1"},
+			err:     money.ErrZeroWeight,
+		},
+		{
+			values:  []*money.Money{},
+			weights: []string{},
+			err:     money.ErrZeroWeight,
+		},
+	}
+
+	for i, test := range table {
+		weights := make([]money.Decimal, len(test.weights))
+		for k, w := range test.weights {
+			weights[k] = money.MustParseDecimal(w)
+		}
+
+		res, err := money.WeightedAvg(test.values, weights)
+		if test.err != err {
+			t.Errorf("#%d - expect error %s, but got %s", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !test.expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect.Amount, res.Amount)
+		}
+	}
+}
+
+func TestMaxMoneyStream(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  []*money.Money
+		expect *money.Money
+		err    error
+	}{
+		{
+			input: []*money.Money{
+				money.MustParse("10.00", "CHF"),
+				money.MustParse("12.50", "CHF"),
+				money.MustParse("-20.00", "CHF"),
+				money.MustParse("12.4999", "CHF"),
+			},
+			expect: money.MustParse("12.50", "CHF"),
+		},
+		{
+			input: []*money.Money{
+				money.MustParse("-10.00", "CHF"),
+			},
+			expect: money.MustParse("-10.00", "CHF"),
+		},
+		{
+			input: []*money.Money{
+				money.MustParse("10.00", "CHF"),
+				money.MustParse("99.00", "EUR"),
+				money.MustParse("12.00", "CHF"),
+			},
+			err: money.ErrCurrencyMismatch,
+		},
+		{
+			input: []*money.Money{
+				nil,
+				money.MustParse("10.00", "CHF"),
+				nil,
+				money.MustParse("12.50", "CHF"),
+			},
+			expect: money.MustParse("12.50", "CHF"),
+		},
+		{
+			input: []*money.Money{},
+			err:   money.ErrNoAmounts,
+		},
+		{
+			input: []*money.Money{nil, nil},
+			err:   money.ErrNoAmounts,
+		},
+	}
+
+	for i, test := range table {
+		ch := make(chan *money.Money, len(test.input))
+		for _, m := range test.input {
+			ch <- m
+		}
+		close(ch)
+
+		res, err := money.MaxMoneyStream(context.Background(), ch)
+		if !errors.Is(err, test.err) {
+			t.Errorf("#%d - expect error %s, but got %s", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !test.expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect.Amount, res.Amount)
+		}
+	}
+}
+
+func TestMaxMoneyStream_Mismatch(t *testing.T) {
+	t.Parallel()
+
+	ch := make(chan *money.Money, 2)
+	ch <- money.MustParse("10.00", "CHF")
+	ch <- money.MustParse("99.00", "EUR")
+	close(ch)
+
+	_, err := money.MaxMoneyStream(context.Background(), ch)
+	if expect := "currency mismatch: 99.00 EUR"; err == nil || expect != err.Error() {
+		t.Errorf("expect error %s, but got %v", expect, err)
+	}
+}
+
+func TestMaxMoneyStream_Cancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan *money.Money)
+	go func() {
+		ch <- money.MustParse("10.00", "CHF")
+		cancel()
+	}()
+
+	if _, err := money.MaxMoneyStream(ctx, ch); err != context.Canceled {
+		t.Errorf("expect error %s, but got %v", context.Canceled, err)
+	}
+}
+
+func TestQuantile(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  []string
+		q      string
+		expect string
+		err    error
+	}{
+		{input: []string{"1", "2", "3"}, q: "0.5", expect: "2"},
+		{input: []string{"1", "2", "3", "4"}, q: "0.5", expect: "2.5"},
+		{input: []string{"1.10", "2.20", "3.30", "10.00"}, q: "0.5", expect: "2.75"},
+		{input: []string{"1", "2", "3", "4"}, q: "0.25", expect: "1.75"},
+		{input: []string{"1", "2", "3", "4"}, q: "0", expect: "1"},
+		{input: []string{"1", "2", "3", "4"}, q: "1", expect: "4"},
+		{input: []string{"-5", "5"}, q: "0.1", expect: "-4"},
+		{input: []string{"7.5"}, q: "0.9", expect: "7.5"},
+		{input: []string{}, q: "0.5", err: money.ErrNoAmounts},
+		{input: []string{"1", "2"}, q: "1.01", err: money.ErrInvalidQuantile},
+		{input: []string{"1", "2"}, q: "-0.5", err: money.ErrInvalidQuantile},
+	}
+
+	for i, test := range table {
+		sorted := make([]money.Decimal, len(test.input))
+		for k, s := range test.input {
+			sorted[k] = money.MustParseDecimal(s)
+		}
+
+		res, err := money.Quantile(sorted, money.MustParseDecimal(test.q))
+		if test.err != err {
+			t.Errorf("#%d - expect error %s, but got %s", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if expect := money.MustParseDecimal(test.expect); !expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, expect, res)
+		}
+	}
+}
+
+func TestPercentOfTotal(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  []*money.Money
+		expect []string
+		err    error
+	}{
+		{
+			input:  []*money.Money{money.MustParse("1.00", "USD"), money.MustParse("1.00", "USD"), money.MustParse("2.00", "USD")},
+			expect: []string{"25", "25", "50"},
+		},
+		{
+			input:  []*money.Money{money.MustParse("10", "USD"), money.MustParse("10", "USD"), money.MustParse("10", "USD")},
+			expect: []string{"33.3333333333333333", "33.3333333333333333", "33.3333333333333333"},
+		},
+		{
+			input:  []*money.Money{money.MustParse("30", "CHF"), money.MustParse("-10", "CHF")},
+			expect: []string{"150", "-50"},
+		},
+		{
+			input: []*money.Money{money.MustParse("10", "USD"), money.MustParse("-10", "USD")},
+			err:   money.ErrZeroTotal,
+		},
+		{
+			input: []*money.Money{},
+			err:   money.ErrZeroTotal,
+		},
+		{
+			input: []*money.Money{money.MustParse("10", "USD"), money.MustParse("10", "CHF")},
+			err:   money.ErrCurrencyMismatch,
+		},
+	}
+
+	for i, test := range table {
+		res, err := money.PercentOfTotal(test.input)
+		if test.err != err {
+			t.Errorf("#%d - expect error %v, but got %v", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if len(test.expect) != len(res) {
+			t.Fatalf("#%d - expect %d percentages, but got %d", i, len(test.expect), len(res))
+		}
+		for k, expect := range test.expect {
+			if !money.MustParseDecimal(expect).Equal(res[k]) {
+				t.Errorf("#%d.%d - expect %s, but got %s", i, k, expect, res[k])
+			}
+		}
+	}
+}
+
+func TestNet(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  []*money.Money
+		expect *money.Money
+		err    error
+	}{
+		{
+			input:  []*money.Money{money.MustParse("100.00", "CHF"), money.MustParse("-30.50", "CHF"), money.MustParse("-20", "CHF")},
+			expect: money.MustParse("49.50", "CHF"),
+		},
+		{
+			input:  []*money.Money{money.MustParse("10", "CHF"), money.MustParse("-10", "CHF")},
+			expect: money.MustParse("0", "CHF"),
+		},
+		{
+			input:  []*money.Money{money.MustParse("-5.25", "USD")},
+			expect: money.MustParse("-5.25", "USD"),
+		},
+		{
+			input: []*money.Money{},
+			err:   money.ErrNoAmounts,
+		},
+		{
+			input: []*money.Money{money.MustParse("10", "CHF"), money.MustParse("-10", "EUR")},
+			err:   money.ErrCurrencyMismatch,
+		},
+		{
+			input: []*money.Money{money.NewMoney(money.DecimalOne(), "ABC")},
+			err:   money.ErrInvalidCurrency,
+		},
+	}
+
+	for i, test := range table {
+		res, err := money.Net(test.input)
+		if test.err != err {
+			t.Errorf("#%d - expect error %v, but got %v", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !test.expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, res)
+		}
+	}
+}
+
+func TestNetByCurrency(t *testing.T) {
+	t.Parallel()
+
+	entries := []*money.Money{
+		money.MustParse("100.00", "CHF"),
+		money.MustParse("-30.00", "EUR"),
+		money.MustParse("-20.00", "CHF"),
+		money.MustParse("12.34", "USD"),
+		money.MustParse("40.00", "EUR"),
+		money.MustParse("-12.34", "USD"),
+	}
+	expect := map[money.Currency]*money.Money{
+		"CHF": money.MustParse("80.00", "CHF"),
+		"EUR": money.MustParse("10.00", "EUR"),
+		"USD": money.MustParse("0", "USD"),
+	}
+
+	res, err := money.NetByCurrency(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(expect) != len(res) {
+		t.Fatalf("expect %d currencies, but got %d", len(expect), len(res))
+	}
+	for c, e := range expect {
+		if !e.Equal(res[c]) {
+			t.Errorf("%s - expect %s, but got %s", c, e, res[c])
+		}
+	}
+
+	res, err = money.NetByCurrency(nil)
+	if err != nil || len(res) != 0 {
+		t.Errorf("expect an empty map, but got %v %v", res, err)
+	}
+
+	_, err = money.NetByCurrency([]*money.Money{money.NewMoney(money.DecimalOne(), "ABC")})
+	if err != money.ErrInvalidCurrency {
+		t.Errorf("expect error %s, but got %v", money.ErrInvalidCurrency, err)
+	}
+}
+
+func TestGroupByCurrency(t *testing.T) {
+	t.Parallel()
+
+	items := []*money.Money{
+		money.MustParse("10.00", "CHF"),
+		money.MustParse("5.00", "EUR"),
+		nil,
+		money.MustParse("1200", "JPY"),
+		money.MustParse("2.50", "CHF"),
+		money.MustParse("-1.25", "EUR"),
+		money.MustParse("300", "JPY"),
+		nil,
+	}
+	expect := map[money.Currency]*money.Money{
+		"CHF": money.MustParse("12.50", "CHF"),
+		"EUR": money.MustParse("3.75", "EUR"),
+		"JPY": money.MustParse("1500", "JPY"),
+	}
+
+	res := money.GroupByCurrency(items)
+	if len(expect) != len(res) {
+		t.Fatalf("expect %d currencies, but got %d", len(expect), len(res))
+	}
+	for c, e := range expect {
+		if !e.EqualExact(res[c]) {
+			t.Errorf("%s - expect %s, but got %s", c, e, res[c])
+		}
+	}
+
+	if res := money.GroupByCurrency([]*money.Money{nil}); len(res) != 0 {
+		t.Errorf("expect an empty map, but got %v", res)
+	}
+}
@@ -0,0 +1,159 @@
+package money
+
+import (
+	"errors"
+	"sort"
+)
+
+var (
+	// ErrInvalidRatio indicates that an allocation was given ratios which are
+	// negative or do not sum up to a positive value
+	ErrInvalidRatio = errors.New("invalid allocation ratio")
+	// ErrInvalidIndex indicates that an allocation was given an index which is
+	// out of range
+	ErrInvalidIndex = errors.New("invalid allocation index")
+	// ErrInvalidPercentSum indicates that percentages do not sum up to 100
+	ErrInvalidPercentSum = errors.New("percentages do not sum up to 100")
+)
+
+// percentTolerance is the maximum difference between 100 and the sum of the
+// percentages given to SplitByPercent
+var percentTolerance = buildDecimal(1, -2)
+
+// AllocateByWeights splits x across weights proportionally, such as
+// x * weight / sum(weights). Each share is expressed in the currency scale and
+// the rounding remainder is distributed by largest fractional part, so the
+// shares always re-sum to x rounded to the currency scale.
+//
+// Weights must be non-negative and sum up to a positive value.
+//
+//   e.g. 100.00 CHF [0.333, 0.333, 0.334]	-> [33.30, 33.30, 33.40]
+func (x *Money) AllocateByWeights(weights []Decimal) ([]*Money, error) {
+	if err := validateRatios(weights); err != nil {
+		return nil, err
+	}
+	return x.allocate(weights), nil
+}
+
+// AllocateDecimal splits x proportionally to decimal ratios, such as 1.5:2.5.
+// It is the same as AllocateByWeights, so the shares always re-sum to x
+// rounded to the currency scale.
+//
+//   e.g. 100.01 USD [1.5, 2.5]	-> [37.50, 62.51]
+func (x *Money) AllocateDecimal(ratios []Decimal) ([]*Money, error) {
+	return x.AllocateByWeights(ratios)
+}
+
+// SplitByPercent splits x across percents, which must sum up to 100 within a
+// tolerance of 0.01. Shares are allocated like AllocateByWeights, so they
+// always re-sum to x rounded to the currency scale.
+//
+//   e.g. 100.00 USD [33.33, 33.33, 33.34]	-> [33.33, 33.33, 33.34]
+//   e.g. 10.00 USD [33.33, 33.33, 33.33]	-> [3.34, 3.33, 3.33]
+func (x *Money) SplitByPercent(percents []Decimal) ([]*Money, error) {
+	if err := validateRatios(percents); err != nil {
+		return nil, err
+	}
+	if SumDecimal(percents...).Sub(hundred).Abs().Cmp(percentTolerance) > 0 {
+		return nil, ErrInvalidPercentSum
+	}
+	return x.allocate(percents), nil
+}
+
+// AllocateTo splits x across ratios proportionally, such as
+// x * ratio / sum(ratios). Each share is expressed in the currency scale and
+// the whole rounding remainder goes to the share at remainderIndex.
+//
+//   e.g. 100.00 CHF 0 [1, 1, 1]	-> [33.34, 33.33, 33.33]
+//   e.g. 100.00 CHF 2 [1, 1, 1]	-> [33.33, 33.33, 33.34]
+func (x *Money) AllocateTo(remainderIndex int, ratios ...int) ([]*Money, error) {
+	if remainderIndex < 0 || remainderIndex >= len(ratios) {
+		return nil, ErrInvalidIndex
+	}
+	weights := make([]Decimal, len(ratios))
+	for i, r := range ratios {
+		weights[i] = buildDecimal(int64(r), 0)
+	}
+	if err := validateRatios(weights); err != nil {
+		return nil, err
+	}
+
+	shares, _, left := x.split(weights)
+	shares[remainderIndex] = shares[remainderIndex].Add(left)
+	return x.parts(shares), nil
+}
+
+// validateRatios checks that all ratios are non-negative and sum up to a
+// positive value
+func validateRatios(ratios []Decimal) error {
+	sum := zero
+	for _, r := range ratios {
+		if r.Sign() == SignNegative {
+			return ErrInvalidRatio
+		}
+		sum = sum.Add(r)
+	}
+	if sum.Sign() != SignPositive {
+		return ErrInvalidRatio
+	}
+	return nil
+}
+
+// allocate splits x proportionally to the given ratios, which are expected to
+// be valid. The units left over by split are handed out one by one to the
+// shares with the largest remainder.
+func (x *Money) allocate(ratios []Decimal) []*Money {
+	shares, remainders, left := x.split(ratios)
+
+	// Distribute the leftover units by largest remainder first
+	unit := buildDecimal(int64(left.Sign()), -int32(x.Currency.Scale()))
+	order := make([]int, len(ratios))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return remainders[order[i]].Abs().Cmp(remainders[order[j]].Abs()) > 0
+	})
+	for i := 0; !left.IsZero(); i++ {
+		k := order[i%len(order)]
+		shares[k] = shares[k].Add(unit)
+		left = left.Sub(unit)
+	}
+	return x.parts(shares)
+}
+
+// split splits x rounded to the currency scale proportionally to the given
+// ratios. Each share is truncated to the currency scale.
+//
+// It returns the shares, the remainder of each share division, and the amount
+// left over to reach the total.
+func (x *Money) split(ratios []Decimal) (shares, remainders []Decimal, left Decimal) {
+	scale := int32(x.Currency.Scale())
+	total := x.Currency.round(x.Amount)
+
+	sum := zero
+	for _, r := range ratios {
+		sum = sum.Add(r)
+	}
+
+	shares = make([]Decimal, len(ratios))
+	remainders = make([]Decimal, len(ratios))
+	left = total
+	for i, r := range ratios {
+		shares[i], remainders[i] = total.Mul(r).quoRem(sum, scale)
+		left = left.Sub(shares[i])
+	}
+	return shares, remainders, left
+}
+
+// parts wraps shares into Money values of the currency of x
+func (x *Money) parts(shares []Decimal) []*Money {
+	parts := make([]*Money, len(shares))
+	for i, share := range shares {
+		parts[i] = &Money{
+			Amount:   share,
+			Currency: x.Currency,
+		}
+	}
+	return parts
+}
@@ -0,0 +1,312 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/deixis/money"
+)
+
+func TestMoney_AllocateByWeights(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input   *money.Money
+		weights []string
+		expect  []string
+		err     error
+	}{
+		{
+			input:   money.MustParse("100.00", "CHF"),
+			weights: []string{"0.333", "0.333", "0.334"},
+			expect:  []string{"33.30", "33.30", "33.40"},
+		},
+		{
+			input:   money.MustParse("100.01", "CHF"),
+			weights: []string{"0.333", "0.333", "0.334"},
+			expect:  []string{"33.30", "33.30", "33.41"},
+		},
+		{
+			input:   money.MustParse("100.00", "CHF"),
+			weights: []string{"1", "1", "1"},
+			expect:  []string{"33.34", "33.33", "33.33"},
+		},
+		{
+			input:   money.MustParse("-100.00", "CHF"),
+			weights: []string{"1", "1", "1"},
+			expect:  []string{"-33.34", "-33.33", "-33.33"},
+		},
+		{
+			input:   money.MustParse("10.00", "USD"),
+			weights: []string{"1.5", "0", "2.5"},
+			expect:  []string{"3.75", "0.00", "6.25"},
+		},
+		{
+			input:   money.MustParse("100", "JPY"),
+			weights: []string{"0.25", "0.25", "0.25"},
+			expect:  []string{"34", "33", "33"},
+		},
+		{
+			input:   money.MustParse("100.00", "CHF"),
+			weights: []string{"0.5", "-0.5", "1"},
+			err:     money.ErrInvalidRatio,
+		},
+		{
+			input:   money.MustParse("100.00", "CHF"),
+			weights: []string{"0", "0"},
+			err:     money.ErrInvalidRatio,
+		},
+		{
+			input:   money.MustParse("100.00", "CHF"),
+			weights: []string{},
+			err:     money.ErrInvalidRatio,
+		},
+	}
+
+	for i, test := range table {
+		weights := make([]money.Decimal, len(test.weights))
+		for k, w := range test.weights {
+			weights[k] = money.MustParseDecimal(w)
+		}
+
+		res, err := test.input.AllocateByWeights(weights)
+		if test.err != err {
+			t.Errorf("#%d - expect error %s, but got %s", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if len(test.expect) != len(res) {
+			t.Fatalf("#%d - expect %d parts, but got %d", i, len(test.expect), len(res))
+		}
+
+		sum := money.MustParseDecimal("0")
+		for k, part := range res {
+			expect := money.MustParse(test.expect[k], test.input.Currency.String())
+			if !expect.Equal(part) {
+				t.Errorf("#%d - expect part %d to be %s, but got %s", i, k, expect.Amount, part.Amount)
+			}
+			sum = sum.Add(part.Amount)
+		}
+		if !sum.Equal(test.input.Amount) {
+			t.Errorf("#%d - expect parts to sum up to %s, but got %s", i, test.input.Amount, sum)
+		}
+	}
+}
+
+func TestMoney_AllocateDecimal(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  *money.Money
+		ratios []string
+		expect []string
+		err    error
+	}{
+		{input: money.MustParse("100.01", "USD"), ratios: []string{"1.5", "2.5"}, expect: []string{"37.50", "62.51"}},
+		{input: money.MustParse("10.00", "USD"), ratios: []string{"0.5", "0.5", "0.5"}, expect: []string{"3.34", "3.33", "3.33"}},
+		{input: money.MustParse("99.99", "EUR"), ratios: []string{"0.7", "1.15", "0", "2.25"}, expect: []string{"17.07", "28.05", "0.00", "54.87"}},
+		{input: money.MustParse("1001", "JPY"), ratios: []string{"1.25", "0.75"}, expect: []string{"626", "375"}},
+		{input: money.MustParse("-100.01", "USD"), ratios: []string{"1.5", "2.5"}, expect: []string{"-37.50", "-62.51"}},
+		{input: money.MustParse("100", "USD"), ratios: []string{"1.5", "-0.5"}, err: money.ErrInvalidRatio},
+		{input: money.MustParse("100", "USD"), ratios: []string{"0", "0.00"}, err: money.ErrInvalidRatio},
+	}
+
+	for i, test := range table {
+		ratios := make([]money.Decimal, len(test.ratios))
+		for k, r := range test.ratios {
+			ratios[k] = money.MustParseDecimal(r)
+		}
+
+		res, err := test.input.AllocateDecimal(ratios)
+		if test.err != err {
+			t.Errorf("#%d - expect error %v, but got %v", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		sum := money.DecimalZero()
+		for k, part := range res {
+			if expect := money.MustParse(test.expect[k], test.input.Currency.String()); !expect.EqualExact(part) {
+				t.Errorf("#%d.%d - expect %s, but got %s", i, k, expect, part)
+			}
+			sum = sum.Add(part.Amount)
+		}
+		if !test.input.Amount.Equal(sum) {
+			t.Errorf("#%d - expect parts to sum up to %s, but got %s", i, test.input.Amount, sum)
+		}
+	}
+}
+
+func TestMoney_AllocateTo(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  *money.Money
+		index  int
+		ratios []int
+		expect []string
+		err    error
+	}{
+		{
+			input:  money.MustParse("100.00", "CHF"),
+			index:  0,
+			ratios: []int{1, 1, 1},
+			expect: []string{"33.34", "33.33", "33.33"},
+		},
+		{
+			input:  money.MustParse("100.00", "CHF"),
+			index:  2,
+			ratios: []int{1, 1, 1},
+			expect: []string{"33.33", "33.33", "33.34"},
+		},
+		{
+			input:  money.MustParse("0.05", "CHF"),
+			index:  1,
+			ratios: []int{1, 1, 1, 1, 1, 1, 1},
+			expect: []string{"0.00", "0.05", "0.00", "0.00", "0.00", "0.00", "0.00"},
+		},
+		{
+			input:  money.MustParse("-10.00", "USD"),
+			index:  1,
+			ratios: []int{70, 30},
+			expect: []string{"-7.00", "-3.00"},
+		},
+		{
+			input:  money.MustParse("-10.00", "USD"),
+			index:  1,
+			ratios: []int{1, 2},
+			expect: []string{"-3.33", "-6.67"},
+		},
+		{
+			input:  money.MustParse("100", "JPY"),
+			index:  0,
+			ratios: []int{1, 3},
+			expect: []string{"25", "75"},
+		},
+		{
+			input:  money.MustParse("100.00", "CHF"),
+			index:  3,
+			ratios: []int{1, 1, 1},
+			err:    money.ErrInvalidIndex,
+		},
+		{
+			input:  money.MustParse("100.00", "CHF"),
+			index:  -1,
+			ratios: []int{1, 1, 1},
+			err:    money.ErrInvalidIndex,
+		},
+		{
+			input:  money.MustParse("100.00", "CHF"),
+			index:  0,
+			ratios: []int{1, -1},
+			err:    money.ErrInvalidRatio,
+		},
+	}
+
+	for i, test := range table {
+		res, err := test.input.AllocateTo(test.index, test.ratios...)
+		if test.err != err {
+			t.Errorf("#%d - expect error %s, but got %s", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if len(test.expect) != len(res) {
+			t.Fatalf("#%d - expect %d parts, but got %d", i, len(test.expect), len(res))
+		}
+
+		sum := money.MustParseDecimal("0")
+		for k, part := range res {
+			expect := money.MustParse(test.expect[k], test.input.Currency.String())
+			if !expect.Equal(part) {
+				t.Errorf("#%d - expect part %d to be %s, but got %s", i, k, expect.Amount, part.Amount)
+			}
+			sum = sum.Add(part.Amount)
+		}
+		if !sum.Equal(test.input.Amount) {
+			t.Errorf("#%d - expect parts to sum up to %s, but got %s", i, test.input.Amount, sum)
+		}
+	}
+}
+
+func TestMoney_SplitByPercent(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input    *money.Money
+		percents []string
+		expect   []string
+		err      error
+	}{
+		{
+			input:    money.MustParse("100.00", "USD"),
+			percents: []string{"33.33", "33.33", "33.34"},
+			expect:   []string{"33.33", "33.33", "33.34"},
+		},
+		{
+			input:    money.MustParse("10.00", "USD"),
+			percents: []string{"33.33", "33.33", "33.33"},
+			expect:   []string{"3.34", "3.33", "3.33"},
+		},
+		{
+			input:    money.MustParse("99.99", "USD"),
+			percents: []string{"50", "25", "25"},
+			expect:   []string{"49.99", "25.00", "25.00"},
+		},
+		{
+			input:    money.MustParse("1000", "JPY"),
+			percents: []string{"12.5", "87.5"},
+			expect:   []string{"125", "875"},
+		},
+		{
+			input:    money.MustParse("100.00", "USD"),
+			percents: []string{"50", "25", "20"},
+			err:      money.ErrInvalidPercentSum,
+		},
+		{
+			input:    money.MustParse("100.00", "USD"),
+			percents: []string{"50", "50.02"},
+			err:      money.ErrInvalidPercentSum,
+		},
+		{
+			input:    money.MustParse("100.00", "USD"),
+			percents: []string{"150", "-50"},
+			err:      money.ErrInvalidRatio,
+		},
+	}
+
+	for i, test := range table {
+		percents := make([]money.Decimal, len(test.percents))
+		for k, p := range test.percents {
+			percents[k] = money.MustParseDecimal(p)
+		}
+
+		res, err := test.input.SplitByPercent(percents)
+		if test.err != err {
+			t.Errorf("#%d - expect error %s, but got %s", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if len(test.expect) != len(res) {
+			t.Fatalf("#%d - expect %d parts, but got %d", i, len(test.expect), len(res))
+		}
+
+		sum := money.MustParseDecimal("0")
+		for k, part := range res {
+			expect := money.MustParse(test.expect[k], test.input.Currency.String())
+			if !expect.Equal(part) {
+				t.Errorf("#%d - expect part %d to be %s, but got %s", i, k, expect.Amount, part.Amount)
+			}
+			sum = sum.Add(part.Amount)
+		}
+		if !sum.Equal(test.input.Amount) {
+			t.Errorf("#%d - expect parts to sum up to %s, but got %s", i, test.input.Amount, sum)
+		}
+	}
+}
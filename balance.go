@@ -0,0 +1,95 @@
+package money
+
+import "errors"
+
+var (
+	// ErrInsufficientFunds indicates that a hold exceeds the available amount
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrInsufficientHold indicates that a capture or a release exceeds the
+	// held amount
+	ErrInsufficientHold = errors.New("insufficient hold")
+	// ErrNegativeAmount indicates that an operation was given a negative
+	// amount
+	ErrNegativeAmount = errors.New("negative amount")
+)
+
+// Balance tracks the funds of an account in a single currency, split between
+// the Available amount and the amount Held by payment authorizations.
+//
+// A hold moves funds from available to held. It is then either captured, which
+// removes the funds from the balance, or released back to available.
+//
+// Balance is not safe for concurrent use.
+type Balance struct {
+	Available *Money
+	Held      *Money
+}
+
+// NewBalance returns a Balance of available funds, with nothing held
+func NewBalance(available *Money) *Balance {
+	return &Balance{
+		Available: available,
+		Held: &Money{
+			Amount:   zero.rescale(-int32(available.Currency.Scale())),
+			Currency: available.Currency,
+		},
+	}
+}
+
+// Hold moves amount from available to held. It returns ErrInsufficientFunds
+// when amount exceeds the available funds.
+//
+//   e.g. {100.00, 0.00} hold 30.00	-> {70.00, 30.00}
+func (b *Balance) Hold(amount *Money) error {
+	if err := b.check(amount); err != nil {
+		return err
+	}
+	if amount.Amount.Cmp(b.Available.Amount) > 0 {
+		return ErrInsufficientFunds
+	}
+	b.Available = NewMoney(b.Available.Amount.Sub(amount.Amount), b.Available.Currency)
+	b.Held = NewMoney(b.Held.Amount.Add(amount.Amount), b.Held.Currency)
+	return nil
+}
+
+// Capture settles amount from the held funds, which leave the balance. It
+// returns ErrInsufficientHold when amount exceeds the held funds.
+//
+//   e.g. {70.00, 30.00} capture 30.00	-> {70.00, 0.00}
+func (b *Balance) Capture(amount *Money) error {
+	if err := b.check(amount); err != nil {
+		return err
+	}
+	if amount.Amount.Cmp(b.Held.Amount) > 0 {
+		return ErrInsufficientHold
+	}
+	b.Held = NewMoney(b.Held.Amount.Sub(amount.Amount), b.Held.Currency)
+	return nil
+}
+
+// Release moves amount from held back to available. It returns
+// ErrInsufficientHold when amount exceeds the held funds.
+//
+//   e.g. {70.00, 30.00} release 10.00	-> {80.00, 20.00}
+func (b *Balance) Release(amount *Money) error {
+	if err := b.check(amount); err != nil {
+		return err
+	}
+	if amount.Amount.Cmp(b.Held.Amount) > 0 {
+		return ErrInsufficientHold
+	}
+	b.Held = NewMoney(b.Held.Amount.Sub(amount.Amount), b.Held.Currency)
+	b.Available = NewMoney(b.Available.Amount.Add(amount.Amount), b.Available.Currency)
+	return nil
+}
+
+// check returns an error when amount cannot be applied to b
+func (b *Balance) check(amount *Money) error {
+	if amount.Currency != b.Available.Currency {
+		return ErrCurrencyMismatch
+	}
+	if amount.Amount.Sign() == SignNegative {
+		return ErrNegativeAmount
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/deixis/money"
+)
+
+func TestBalance(t *testing.T) {
+	t.Parallel()
+
+	b := money.NewBalance(money.MustParse("100.00", "USD"))
+
+	// Steps are applied in order to the same balance. Failed steps must leave
+	// it untouched.
+	steps := []struct {
+		op        func(*money.Money) error
+		amount    *money.Money
+		available string
+		held      string
+		err       error
+	}{
+		{op: b.Hold, amount: money.MustParse("30.00", "USD"), available: "70.00", held: "30.00"},
+		{op: b.Capture, amount: money.MustParse("20.00", "USD"), available: "70.00", held: "10.00"},
+		{op: b.Release, amount: money.MustParse("10.00", "USD"), available: "80.00", held: "0.00"},
+		{op: b.Hold, amount: money.MustParse("80.01", "USD"), available: "80.00", held: "0.00", err: money.ErrInsufficientFunds},
+		{op: b.Hold, amount: money.MustParse("80.00", "EUR"), available: "80.00", held: "0.00", err: money.ErrCurrencyMismatch},
+		{op: b.Hold, amount: money.MustParse("-1.00", "USD"), available: "80.00", held: "0.00", err: money.ErrNegativeAmount},
+		{op: b.Hold, amount: money.MustParse("80.00", "USD"), available: "0.00", held: "80.00"},
+		{op: b.Capture, amount: money.MustParse("80.01", "USD"), available: "0.00", held: "80.00", err: money.ErrInsufficientHold},
+		{op: b.Release, amount: money.MustParse("80.01", "USD"), available: "0.00", held: "80.00", err: money.ErrInsufficientHold},
+		{op: b.Capture, amount: money.MustParse("80.00", "USD"), available: "0.00", held: "0.00"},
+	}
+
+	for i, step := range steps {
+		if err := step.op(step.amount); step.err != err {
+			t.Errorf("#%d - expect error %v, but got %v", i, step.err, err)
+		}
+		if expect := money.MustParse(step.available, "USD"); !expect.Equal(b.Available) {
+			t.Errorf("#%d - expect available %s, but got %s", i, expect, b.Available)
+		}
+		if expect := money.MustParse(step.held, "USD"); !expect.Equal(b.Held) {
+			t.Errorf("#%d - expect held %s, but got %s", i, expect, b.Held)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package money
+
+import "sync"
+
+// Config gathers the package settings. It is read with GetConfig and replaced
+// atomically with SetConfig.
+type Config struct {
+	// DivisionPrecision is the number of decimal places in the result when it
+	// doesn't divide exactly.
+	//
+	// Example:
+	//
+	//     d1 := decimal.NewFromFloat(2).Div(decimal.NewFromFloat(3)
+	//     d1.String() // output: "0.6666666666666667"
+	//     d2 := decimal.NewFromFloat(2).Div(decimal.NewFromFloat(30000)
+	//     d2.String() // output: "0.0000666666666667"
+	//     d3 := decimal.NewFromFloat(20000).Div(decimal.NewFromFloat(3)
+	//     d3.String() // output: "6666.6666666666666667"
+	//     c := money.GetConfig()
+	//     c.DivisionPrecision = 3
+	//     money.SetConfig(c)
+	//     d4 := decimal.NewFromFloat(2).Div(decimal.NewFromFloat(3)
+	//     d4.String() // output: "0.667"
+	//
+	DivisionPrecision int
+
+	// MarshalJSONWithoutQuotes should be set to true if you want the decimal to
+	// be JSON marshaled as a number, insteaddof as a string.
+	// WARNING: this is dangerous for decimals with many digits, since many JSON
+	// unmarshallers (ex: Javascript's) will unmarshal JSON numbers to IEEE 754
+	// double-precision floating point numbers, which means you can potentially
+	// silently lose precision.
+	MarshalJSONWithoutQuotes bool
+
+	// MaxDecimalDigits is the maximum number of digits accepted by ParseDecimal
+	// and ParseDecimalBytes. Longer values are rejected with ErrInvalidDecimal,
+	// so untrusted input cannot exhaust memory. Zero or a negative value disables
+	// the limit.
+	//
+	// Parsers accepting exponent notation, such as FromShopspring and
+	// UnmarshalJSON, count the digits plus the absolute exponent, so 1e999999999
+	// is rejected as well.
+	MaxDecimalDigits int
+
+	// MaxMoneyMagnitude is the largest absolute amount accepted by Parse,
+	// ParseToken, ParseWithSymbol, and the Money and GoogleMoney JSON decoders.
+	// Larger amounts are rejected with ErrMagnitudeExceeded, which guards
+	// ledgers against mistyped or malicious amounts. Zero disables the limit,
+	// which is the default.
+	MaxMoneyMagnitude Decimal
+}
+
+var (
+	configMu sync.RWMutex
+	config   = Config{
+		DivisionPrecision:        16,
+		MarshalJSONWithoutQuotes: false,
+		MaxDecimalDigits:         1000,
+	}
+)
+
+// GetConfig returns the current package settings
+func GetConfig() Config {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return config
+}
+
+// SetConfig replaces the package settings. It is safe to call concurrently
+// with any other function, but is meant to be called once during setup.
+//
+// Fields are not merged, so it should be used as:
+//
+//     c := money.GetConfig()
+//     c.DivisionPrecision = 8
+//     money.SetConfig(c)
+//
+func SetConfig(c Config) {
+	configMu.Lock()
+	defer configMu.Unlock()
+	config = c
+}
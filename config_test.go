@@ -0,0 +1,65 @@
+package money_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/deixis/money"
+)
+
+func TestGetConfig(t *testing.T) {
+	t.Parallel()
+
+	expect := money.Config{
+		DivisionPrecision:        16,
+		MarshalJSONWithoutQuotes: false,
+		MaxDecimalDigits:         1000,
+	}
+	if res := money.GetConfig(); !reflect.DeepEqual(expect, res) {
+		t.Errorf("expect default config %+v, but got %+v", expect, res)
+	}
+}
+
+func TestSetConfig_Race(t *testing.T) {
+	t.Parallel()
+
+	// Setting the defaults keeps the result of concurrent tests unchanged
+	c := money.GetConfig()
+	x := money.MustParseDecimal("2")
+	y := money.MustParseDecimal("3")
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for n := 0; n < 100; n++ {
+				if res := x.Div(y); res.String() != "0.6666666666666667" {
+					t.Errorf("expect 0.6666666666666667, but got %s", res)
+				}
+				data, err := json.Marshal(x)
+				if err != nil {
+					t.Error(err)
+				}
+				if string(data) != `"2.0"` {
+					t.Errorf("expect %s, but got %s", `"2.0"`, data)
+				}
+				_ = money.GetConfig()
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		money.SetConfig(c)
+	}()
+	wg.Wait()
+
+	if res := money.GetConfig(); !reflect.DeepEqual(c, res) {
+		t.Errorf("expect config %+v, but got %+v", c, res)
+	}
+}
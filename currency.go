@@ -3,6 +3,7 @@ package money
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 
@@ -58,19 +59,64 @@ func ParseCurrency(s string) (Currency, error) {
 	return Currency(u.String()), nil
 }
 
+// SortCurrencies sorts cs in place for display, with the preferred currencies
+// first in the given order, then the others in alphabetical order.
+//
+//   e.g. [USD, CHF, EUR, JPY] preferred CHF	-> [CHF, EUR, JPY, USD]
+func SortCurrencies(cs []Currency, preferred ...Currency) {
+	rank := make(map[Currency]int, len(preferred))
+	for i, c := range preferred {
+		if _, ok := rank[c]; !ok {
+			rank[c] = i
+		}
+	}
+	sort.Slice(cs, func(i, j int) bool {
+		ri, iok := rank[cs[i]]
+		rj, jok := rank[cs[j]]
+		switch {
+		case iok && jok:
+			return ri < rj
+		case iok != jok:
+			return iok
+		}
+		return cs[i] < cs[j]
+	})
+}
+
 // Scale returns the standard currency scale
 func (c Currency) Scale() int {
-	scale, _ := currency.Kind(RoundingStandard.kind()).Rounding(*c.currency())
+	return c.FractionDigits(RoundingStandard)
+}
+
+// FractionDigits returns the number of decimals shown by formatters for the
+// given kind. Unofficial currencies use their registered scale for all kinds.
+//
+//   e.g. CHF standard	-> 2
+//   e.g. HUF cash		-> 0
+func (c Currency) FractionDigits(kind RoundingKind) int {
+	if u, ok := c.unoficial(); ok {
+		return u.scale
+	}
+	scale, _ := currency.Kind(kind.kind()).Rounding(*c.currency())
 	return scale
 }
 
 // RoundUnit returns a rounding unit for the given kind
 func (c Currency) RoundUnit(kind RoundingKind) Decimal {
+	if u, ok := c.unoficial(); ok {
+		return buildDecimal(1, int32(-u.scale))
+	}
+
 	// Get rounding for the currency
 	scale, inc := currency.Kind(kind.kind()).Rounding(*c.currency())
 	return buildDecimal(int64(inc), int32(scale*-1))
 }
 
+// round rounds x to the currency standard scale
+func (c Currency) round(x Decimal) Decimal {
+	return x.Round(int32(c.Scale()))
+}
+
 // String returns the ISO 4217 representation of a currency (e.g. CHF)
 func (c Currency) String() string {
 	return string(c)
@@ -82,6 +128,13 @@ func (c Currency) Validate() error {
 	return err
 }
 
+// isOfficial returns whether c is a valid ISO 4217 currency, as opposed to an
+// unofficial one registered with RegisterUnoficialCurrency
+func (c Currency) isOfficial() bool {
+	_, err := currency.ParseISO(c.String())
+	return err == nil
+}
+
 // UnmarshalJSON implements the json.Unmarshaler interface.
 func (c *Currency) UnmarshalJSON(data []byte) error {
 	if len(data) > 2 && data[0] == '"' && data[len(data)-1] == '"' {
@@ -93,6 +146,9 @@ func (c *Currency) UnmarshalJSON(data []byte) error {
 		*c = currency
 		return nil
 	}
+	if !isJSONEmpty(data) {
+		return fmt.Errorf("Error parsing money/currency '%s': %s", data, ErrInvalidCurrency)
+	}
 
 	// Accept empty data. The Validate function should be used to make sure it
 	// is valid
@@ -131,16 +187,41 @@ const (
 	nullCurrency Currency = ""
 )
 
+// defaultUnoficialScale is the scale of unofficial currencies registered
+// without one. It matches the CLDR default of 2 fraction digits.
+const defaultUnoficialScale = 2
+
+// unoficialCurrency holds the properties of a registered unofficial currency
+type unoficialCurrency struct {
+	scale int
+}
+
 var unoficialCurrencies = sync.Map{}
 
 // RegisterUnoficialCurrency registers a currency code that is not a valid
-// ISO 4217 currency code.
+// ISO 4217 currency code, with a scale of 2.
 //
 // This can be used for crypto currency codes, such as ETH, DAI, USDC, ...
 func RegisterUnoficialCurrency(code string) {
+	RegisterUnoficialCurrencyScale(code, defaultUnoficialScale)
+}
+
+// RegisterUnoficialCurrencyScale is like RegisterUnoficialCurrency, but also
+// sets the currency scale (e.g. 8 for XBT satoshis).
+func RegisterUnoficialCurrencyScale(code string, scale int) {
 	code = strings.TrimSpace(strings.ToUpper(code))
 
-	if _, err := ParseCurrency(code); err == ErrInvalidCurrency {
-		unoficialCurrencies.Store(code, true)
+	if !Currency(code).isOfficial() {
+		unoficialCurrencies.Store(code, unoficialCurrency{scale: scale})
+	}
+}
+
+// unoficial returns the properties of c when it is a registered unofficial
+// currency
+func (c Currency) unoficial() (unoficialCurrency, bool) {
+	v, ok := unoficialCurrencies.Load(c.String())
+	if !ok {
+		return unoficialCurrency{}, false
 	}
+	return v.(unoficialCurrency), true
 }
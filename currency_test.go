@@ -1,6 +1,7 @@
 package money_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/deixis/money"
@@ -116,3 +117,161 @@ func Test_UnoficialCurrency(t *testing.T) {
 		}
 	}
 }
+
+func TestCurrency_Scale(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input      money.Currency
+		scale      int
+		standard   string
+		cash       string
+		accounting string
+	}{
+		// 3 decimals
+		{input: "BHD", scale: 3, standard: "0.001", cash: "0.001", accounting: "0.001"},
+		{input: "KWD", scale: 3, standard: "0.001", cash: "0.001", accounting: "0.001"},
+		// 4 decimals
+		{input: "CLF", scale: 4, standard: "0.0001", cash: "0.0001", accounting: "0.0001"},
+		// 0 decimal
+		{input: "JPY", scale: 0, standard: "1", cash: "1", accounting: "1"},
+		{input: "KRW", scale: 0, standard: "1", cash: "1", accounting: "1"},
+		// 2 decimals with a cash unit
+		{input: "CHF", scale: 2, standard: "0.01", cash: "0.05", accounting: "0.01"},
+		{input: "HUF", scale: 2, standard: "0.01", cash: "1", accounting: "0.01"},
+		{input: "USD", scale: 2, standard: "0.01", cash: "0.01", accounting: "0.01"},
+	}
+
+	for i, test := range table {
+		if test.scale != test.input.Scale() {
+			t.Errorf("#%d - expect scale %d, but got %d - %s", i, test.scale, test.input.Scale(), test.input)
+		}
+
+		units := []struct {
+			kind   money.RoundingKind
+			expect string
+		}{
+			{kind: money.RoundingStandard, expect: test.standard},
+			{kind: money.RoundingCash, expect: test.cash},
+			{kind: money.RoundingAccounting, expect: test.accounting},
+		}
+		for _, u := range units {
+			expect := money.MustParseDecimal(u.expect)
+			res := test.input.RoundUnit(u.kind)
+			if !expect.Equal(res) {
+				t.Errorf("#%d - expect %s unit %s, but got %s - %s", i, u.kind, expect, res, test.input)
+			}
+			if res.Exponent() > 0 || -res.Exponent() > int32(test.scale) {
+				t.Errorf("#%d - expect %s unit exponent within scale %d, but got %d - %s", i, u.kind, test.scale, res.Exponent(), test.input)
+			}
+		}
+
+		// Rounding to the standard unit must land on the currency scale
+		x := money.MustParseDecimal("1.23456789")
+		rounded := money.Round(x, test.input.RoundUnit(money.RoundingStandard), money.RoundToNearest)
+		if !rounded.Equal(x.Round(int32(test.scale))) {
+			t.Errorf("#%d - expect %s, but got %s - %s", i, x.Round(int32(test.scale)), rounded, test.input)
+		}
+	}
+}
+
+func TestCurrency_FractionDigits(t *testing.T) {
+	t.Parallel()
+
+	money.RegisterUnoficialCurrencyScale("SATS", 0)
+	money.RegisterUnoficialCurrencyScale("WETH", 18)
+	money.RegisterUnoficialCurrency("USDT")
+
+	table := []struct {
+		input      money.Currency
+		standard   int
+		cash       int
+		accounting int
+	}{
+		{input: "CHF", standard: 2, cash: 2, accounting: 2},
+		{input: "HUF", standard: 2, cash: 0, accounting: 2},
+		{input: "JPY", standard: 0, cash: 0, accounting: 0},
+		{input: "BHD", standard: 3, cash: 3, accounting: 3},
+		// Unofficial currencies
+		{input: "SATS", standard: 0, cash: 0, accounting: 0},
+		{input: "WETH", standard: 18, cash: 18, accounting: 18},
+		{input: "USDT", standard: 2, cash: 2, accounting: 2},
+	}
+
+	for i, test := range table {
+		kinds := []struct {
+			kind   money.RoundingKind
+			expect int
+		}{
+			{kind: money.RoundingStandard, expect: test.standard},
+			{kind: money.RoundingCash, expect: test.cash},
+			{kind: money.RoundingAccounting, expect: test.accounting},
+		}
+		for _, k := range kinds {
+			if res := test.input.FractionDigits(k.kind); k.expect != res {
+				t.Errorf("#%d - expect %s %d, but got %d - %s", i, k.kind, k.expect, res, test.input)
+			}
+		}
+		if res := test.input.Scale(); test.standard != res {
+			t.Errorf("#%d - expect scale %d, but got %d - %s", i, test.standard, res, test.input)
+		}
+	}
+
+	unit := money.MustParseCurrency("WETH").RoundUnit(money.RoundingCash)
+	if expect := money.MustParseDecimal("0.000000000000000001"); !expect.Equal(unit) {
+		t.Errorf("expect unit %s, but got %s", expect, unit)
+	}
+
+	// The unit is owned by the caller, so mutating it leaves one intact
+	if err := unit.SetString("7"); err != nil {
+		t.Fatalf("expect no error, but got %s", err)
+	}
+	if res := money.MustParseCurrency("WETH").RoundUnit(money.RoundingCash); !res.Equal(money.MustParseDecimal("0.000000000000000001")) {
+		t.Errorf("expect unit to be unchanged, but got %s", res)
+	}
+	if res := money.MustParseDecimal("1.5").Round(0); !res.Equal(money.MustParseDecimal("2")) {
+		t.Errorf("expect 1.5 to round to 2, but got %s", res)
+	}
+}
+
+func TestSortCurrencies(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input     []money.Currency
+		preferred []money.Currency
+		expect    []money.Currency
+	}{
+		{
+			input:  []money.Currency{"USD", "CHF", "JPY", "EUR"},
+			expect: []money.Currency{"CHF", "EUR", "JPY", "USD"},
+		},
+		{
+			input:     []money.Currency{"USD", "CHF", "JPY", "EUR"},
+			preferred: []money.Currency{"CHF"},
+			expect:    []money.Currency{"CHF", "EUR", "JPY", "USD"},
+		},
+		{
+			input:     []money.Currency{"USD", "CHF", "JPY", "EUR"},
+			preferred: []money.Currency{"USD", "EUR"},
+			expect:    []money.Currency{"USD", "EUR", "CHF", "JPY"},
+		},
+		{
+			input:     []money.Currency{"USD", "CHF", "JPY", "EUR"},
+			preferred: []money.Currency{"GBP", "JPY", "USD", "JPY"},
+			expect:    []money.Currency{"JPY", "USD", "CHF", "EUR"},
+		},
+		{
+			input:     []money.Currency{},
+			preferred: []money.Currency{"CHF"},
+			expect:    []money.Currency{},
+		},
+	}
+
+	for i, test := range table {
+		money.SortCurrencies(test.input, test.preferred...)
+		if !reflect.DeepEqual(test.expect, test.input) {
+			t.Errorf("#%d - expect %v, but got %v", i, test.expect, test.input)
+		}
+	}
+}
@@ -7,49 +7,22 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
-	"unicode"
-
-	"golang.org/x/text/number"
+	"unicode/utf8"
 )
 
 // TODO: Replace with https://github.com/cockroachdb/apd
 // TODO: Use currency to define the precision
 // TODO: Do not require to define a precision
 
-// divisionPrecision is the number of decimal places in the result when it
-// doesn't divide exactly.
-//
-// Example:
-//
-//     d1 := decimal.NewFromFloat(2).Div(decimal.NewFromFloat(3)
-//     d1.String() // output: "0.6666666666666667"
-//     d2 := decimal.NewFromFloat(2).Div(decimal.NewFromFloat(30000)
-//     d2.String() // output: "0.0000666666666667"
-//     d3 := decimal.NewFromFloat(20000).Div(decimal.NewFromFloat(3)
-//     d3.String() // output: "6666.6666666666666667"
-//     decimal.DivisionPrecision = 3
-//     d4 := decimal.NewFromFloat(2).Div(decimal.NewFromFloat(3)
-//     d4.String() // output: "0.667"
-//
-var divisionPrecision = 16
-
-// marshalJSONWithoutQuotes should be set to true if you want the decimal to
-// be JSON marshaled as a number, insteaddof as a string.
-// WARNING: this is dangerous for decimals with many digits, since many JSON
-// unmarshallers (ex: Javascript's) will unmarshal JSON numbers to IEEE 754
-// double-precision floating point numbers, which means you can potentially
-// silently lose precision.
-var marshalJSONWithoutQuotes = false
-
 // decSeparator is the decimal separator symbol
-const decSeparator = "."
-
-// allowedDecimalRunes contains the list of allowed runes that are not a decimal
-// in a string representation
-var allowedDecimalRunes = []rune{'+', '-', '.'}
+const decSeparator = '.'
 
+// Sign constants are the only values returned by Decimal.Sign and Money.Sign,
+// so callers can switch on them.
 const (
 	// SignPositive is the number returned by Sign() when a decimal is positive
 	SignPositive = 1
@@ -61,9 +34,20 @@ const (
 
 var (
 	zero    = buildDecimal(0, 1)
+	half    = buildDecimal(5, -1)
+	one     = buildDecimal(1, 0)
+	two     = buildDecimal(2, 0)
 	ten     = buildDecimal(10, 0)
 	hundred = buildDecimal(100, 0)
 
+	pow10Ints = func() []*big.Int {
+		ints := make([]*big.Int, 20)
+		for i := range ints {
+			ints[i] = new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(i)), nil)
+		}
+		return ints
+	}()
+
 	zeroInt = big.NewInt(0)
 	oneInt  = big.NewInt(1)
 	fiveInt = big.NewInt(5)
@@ -73,8 +57,51 @@ var (
 var (
 	// ErrInvalidDecimal indicates that the string is not a valid decimal
 	ErrInvalidDecimal = errors.New("invalid decimal")
+	// ErrNegativeBase indicates that a negative decimal was raised to a
+	// fractional power, which has no real result
+	ErrNegativeBase = errors.New("negative base with fractional exponent")
+	// ErrInexactFloat indicates that a float does not exactly hold the decimal
+	// value it represents
+	ErrInexactFloat = errors.New("inexact float")
+	// ErrOverflow indicates that a decimal does not fit in the requested
+	// integer type
+	ErrOverflow = errors.New("decimal overflow")
+	// ErrDivisionByZero indicates that a decimal was divided by zero
+	ErrDivisionByZero = errors.New("division by zero")
+	// ErrInvalidPrecision indicates that a precision is too far from the
+	// exponent of a decimal to be applied safely
+	ErrInvalidPrecision = errors.New("invalid precision")
+	// ErrNoAllowedValues indicates that a decimal was snapped to an empty set
+	// of allowed values
+	ErrNoAllowedValues = errors.New("no allowed values")
 )
 
+// Accuracy describes the error of a lossy conversion of a Decimal, relative to
+// the exact value. It mirrors big.Accuracy.
+type Accuracy int8
+
+// Constants describing the Accuracy of a conversion
+const (
+	// Below indicates that the result is smaller than the exact value
+	Below Accuracy = -1
+	// Exact indicates that the result is the exact value
+	Exact Accuracy = 0
+	// Above indicates that the result is larger than the exact value
+	Above Accuracy = +1
+)
+
+func (a Accuracy) String() string {
+	switch a {
+	case Below:
+		return "Below"
+	case Exact:
+		return "Exact"
+	case Above:
+		return "Above"
+	}
+	return "Accuracy(" + strconv.Itoa(int(a)) + ")"
+}
+
 // Decimal represents a fixed-point decimal. It is immutable.
 // number = value * 10 ^ exp
 type Decimal struct {
@@ -82,6 +109,44 @@ type Decimal struct {
 	exp   int32
 }
 
+// DecimalZero returns a decimal representing 0
+func DecimalZero() Decimal {
+	return zero.clone()
+}
+
+// DecimalOne returns a decimal representing 1
+func DecimalOne() Decimal {
+	return one.clone()
+}
+
+// DecimalHundred returns a decimal representing 100
+func DecimalHundred() Decimal {
+	return hundred.clone()
+}
+
+// clone returns a copy of d with its own coefficient storage, so package
+// values are never handed out to callers.
+func (d Decimal) clone() Decimal {
+	var c Decimal
+	c.value.Set(&d.value)
+	c.exp = d.exp
+	return c
+}
+
+// ParseDecimalPreserveScale parses s like ParseDecimal, which already keeps
+// the scale as written, trailing zeros included. It exists to make that
+// intent explicit at call sites that would otherwise be tempted to go through
+// a float64.
+//
+// A float64 has no notion of trailing zeros, so NewDecimal(120.50) yields
+// 120.5, whereas ParseDecimalPreserveScale("120.50") yields 120.50. When only
+// the float is available, WithScale restores the intended scale.
+//
+//   e.g. 120.50	-> 120.50, Exponent -2
+func ParseDecimalPreserveScale(s string) (Decimal, error) {
+	return ParseDecimal(s)
+}
+
 func MustParseDecimal(value string) Decimal {
 	d, err := ParseDecimal(value)
 	if err != nil {
@@ -96,73 +161,159 @@ func MustParseDecimal(value string) Decimal {
 //   e.g. 120.0 	-> Precision 1
 //   e.g. 123.456	-> Precision 3
 func ParseDecimal(value string) (Decimal, error) {
-	var ints string
-	var exp int64
-
-	// Check format.
-	// It avoids to parse valid big int values, such as:
-	//  - exponents
-	//  - infinity
-	//  - base 2, 16, ...
-	for _, c := range value {
-		if unicode.IsDigit(c) {
-			continue
-		}
+	return parseDecimal([]byte(value), decSeparator)
+}
+
+// ParseDecimalBytes is like ParseDecimal, but parses the value directly from
+// a byte slice, which avoids converting it to a string.
+func ParseDecimalBytes(value []byte) (Decimal, error) {
+	return parseDecimal(value, decSeparator)
+}
+
+// ParseDecimalSep is like ParseDecimal, but uses sep as the decimal separator.
+// It does not support digit grouping.
+//
+//   e.g. ParseDecimalSep("120,50", ',')	-> 120.50
+func ParseDecimalSep(value string, sep rune) (Decimal, error) {
+	return parseDecimal([]byte(value), sep)
+}
 
-		var allowed bool
-		for _, r := range allowedDecimalRunes {
-			if c == r {
-				allowed = true
-				break
+// FromShopspring parses s as formatted by github.com/shopspring/decimal.
+// It is like ParseDecimal, but also accepts the exponent notation supported by
+// shopspring's NewFromString.
+//
+//   e.g. 120.5	-> 120.5
+//   e.g. 1.205e2	-> 120.5
+func FromShopspring(s string) (Decimal, error) {
+	d, err := parseJSONNumber([]byte(s))
+	if err != nil {
+		return zero, ErrInvalidDecimal
+	}
+	return d, nil
+}
+
+// SetString sets d to the value of s, parsed like ParseDecimal. On error, d is
+// set to zero.
+//
+// Unlike big.Int.SetString, it does not reuse the storage of d. Copies of a
+// Decimal share that storage, so overwriting it would silently change every
+// earlier copy of d, such as the ones appended to a slice in a parsing loop.
+func (d *Decimal) SetString(s string) error {
+	var x Decimal
+	if err := x.parse([]byte(s), decSeparator); err != nil {
+		*d = Decimal{}
+		return err
+	}
+	*d = x
+	return nil
+}
+
+// parseDecimal parses a value formatted as [sign] digits [sep digits].
+//
+// It avoids to parse valid big int values, such as:
+//  - exponents
+//  - infinity
+//  - base 2, 16, ...
+func parseDecimal(value []byte, sep rune) (Decimal, error) {
+	var d Decimal
+	if err := d.parse(value, sep); err != nil {
+		return zero, err
+	}
+	return d, nil
+}
+
+// parse is like parseDecimal, but sets d to the parsed value. It reuses the
+// storage of d.value, so d must not share it with another decimal.
+func (d *Decimal) parse(value []byte, sep rune) error {
+	var neg bool
+	if len(value) > 0 && (value[0] == '+' || value[0] == '-') {
+		neg = value[0] == '-'
+		value = value[1:]
+	}
+
+	// Digits are accumulated by words of up to 19 digits, which fit in an
+	// uint64, before being added to the coefficient.
+	var word big.Int
+	var w uint64
+	var wn, digits int
+	var frac int64 = -1
+	maxDigits := GetConfig().MaxDecimalDigits
+	for len(value) > 0 {
+		c := value[0]
+		if c < '0' || c > '9' {
+			r, size := utf8.DecodeRune(value)
+			if r != sep || frac >= 0 {
+				return ErrInvalidDecimal
 			}
+			frac = 0
+			value = value[size:]
+			continue
 		}
 
-		if !allowed {
-			return zero, ErrInvalidDecimal
+		w = w*10 + uint64(c-'0')
+		wn++
+		digits++
+		if digits > maxDigits && maxDigits > 0 {
+			return ErrInvalidDecimal
+		}
+		if frac >= 0 {
+			frac++
 		}
+		if wn == 19 {
+			if digits == wn {
+				d.value.SetUint64(w)
+			} else {
+				d.value.Mul(&d.value, pow10Int(int64(wn)))
+				d.value.Add(&d.value, word.SetUint64(w))
+			}
+			w, wn = 0, 0
+		}
+		value = value[1:]
 	}
-
-	parts := strings.Split(value, decSeparator)
-	switch len(parts) {
-	case 1:
-		ints = parts[0]
-		exp = 0
-	case 2:
-		// strip the insignificant digits for more accurate comparisons.
-		ints = parts[0] + parts[1]
-		expInt := -len(parts[1])
-		exp += int64(expInt)
-	default:
-		return zero, ErrInvalidDecimal
+	if digits == 0 {
+		return ErrInvalidDecimal
 	}
-
-	dValue := new(big.Int)
-	if _, ok := dValue.SetString(ints, 10); !ok {
-		return zero, ErrInvalidDecimal
+	if digits == wn {
+		d.value.SetUint64(w)
+	} else if wn > 0 {
+		d.value.Mul(&d.value, pow10Int(int64(wn)))
+		d.value.Add(&d.value, word.SetUint64(w))
 	}
-	if exp < math.MinInt32 || exp > math.MaxInt32 {
-		return zero, ErrInvalidDecimal
+	if neg {
+		d.value.Neg(&d.value)
 	}
 
-	return Decimal{
-		value: *dValue,
-		exp:   int32(exp),
-	}, nil
+	d.exp = 0
+	if frac > 0 {
+		if -frac < math.MinInt32 {
+			return ErrInvalidDecimal
+		}
+		d.exp = int32(-frac)
+	}
+	return nil
 }
 
 // NewDecimal creates a Decimal from a float
 //
+// The float has no notion of scale, so trailing zeros are lost: 120.50 yields
+// 120.5. Use ParseDecimalPreserveScale when the original string is available,
+// or WithScale to set the intended scale.
+//
 // Example:
 //
 //     NewFromFloat(123.45678901234567).String() // output: "123.4567890123456"
 //     NewFromFloat(.00000000000000001).String() // output: "0.00000000000000001"
 //
-// NOTE: errors occur on NaN, +/-inf
+// It returns ErrInvalidDecimal on NaN and +/-Inf.
 func NewDecimal(value float64) (Decimal, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return zero, ErrInvalidDecimal
+	}
 	floor := math.Floor(value)
 
-	// fast path, where float is an int
-	if floor == value && value <= math.MaxInt64 && value >= math.MinInt64 {
+	// fast path, where float is an int. float64(math.MaxInt64) is 2^63, which
+	// overflows an int64, so the upper bound is exclusive.
+	if floor == value && value < math.MaxInt64 && value >= math.MinInt64 {
 		return buildDecimal(int64(value), 0), nil
 	}
 
@@ -175,6 +326,63 @@ func NewDecimal(value float64) (Decimal, error) {
 	return dec, nil
 }
 
+// Number is the set of integer and floating-point types accepted by
+// NewFromNumber
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// NewFromNumber creates a Decimal from any integer or floating-point value.
+// Integers are converted exactly, whereas floats are converted like
+// NewDecimal, so they return ErrInvalidDecimal on NaN and +/-Inf. A float32
+// keeps its own shortest representation (e.g. 0.1 rather than
+// 0.10000000149011612).
+func NewFromNumber[T Number](v T) (Decimal, error) {
+	// The kind is taken from reflection rather than a type switch, so named
+	// types such as `type rate float32` are covered by the ~ constraint too.
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Float32:
+		f := float64(v)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return zero, ErrInvalidDecimal
+		}
+		return ParseDecimal(strconv.FormatFloat(f, 'f', -1, 32))
+	case reflect.Float64:
+		return NewDecimal(float64(v))
+	}
+
+	if v < 0 {
+		return buildDecimal(int64(v), 0), nil
+	}
+	var d Decimal
+	d.value.SetUint64(uint64(v))
+	return d, nil
+}
+
+// NewDecimalExact is like NewDecimal, but returns ErrInexactFloat when the
+// float is not exactly equal to its shortest decimal representation.
+//
+// Example:
+//
+//     NewDecimalExact(0.5) // 0.5
+//     NewDecimalExact(0.1) // ErrInexactFloat, 0.1 is stored as 0.1000000000000000055511151231257827...
+//
+func NewDecimalExact(value float64) (Decimal, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return zero, ErrInvalidDecimal
+	}
+	dec, err := NewDecimal(value)
+	if err != nil {
+		return zero, err
+	}
+	if dec.Rat().Cmp(new(big.Rat).SetFloat64(value)) != 0 {
+		return zero, ErrInexactFloat
+	}
+	return dec, nil
+}
+
 // MinDecimal returns the smallest Decimal that was passed in the arguments.
 //
 // To call this function with an array, you must do:
@@ -209,6 +417,107 @@ func MaxDecimal(first Decimal, rest ...Decimal) Decimal {
 	return ans
 }
 
+// AlignScale returns a copy of ds where all decimals are rescaled to the
+// smallest exponent among them, i.e. the largest precision.
+//
+//   e.g. [1.5, 2, 0.125]	-> [1.500, 2.000, 0.125]
+func AlignScale(ds ...Decimal) []Decimal {
+	aligned := make([]Decimal, len(ds))
+	if len(ds) == 0 {
+		return aligned
+	}
+	exp := minExponent(ds)
+	for i, d := range ds {
+		aligned[i] = d.rescale(exp)
+	}
+	return aligned
+}
+
+// SumDecimal returns the sum of ds, with the precision of the most precise
+// operand. Unlike repeated calls to Add, the operands are aligned to a single
+// exponent and accumulated in one pass.
+//
+//   e.g. [1.5, 2, 0.125]	-> 3.625
+func SumDecimal(ds ...Decimal) Decimal {
+	if len(ds) == 0 {
+		return Decimal{}
+	}
+
+	exp := minExponent(ds)
+	var sum, scaled big.Int
+	for i := range ds {
+		if ds[i].exp == exp {
+			sum.Add(&sum, &ds[i].value)
+			continue
+		}
+		scaled.Mul(&ds[i].value, pow10Int(int64(ds[i].exp)-int64(exp)))
+		sum.Add(&sum, &scaled)
+	}
+	return Decimal{value: sum, exp: exp}
+}
+
+// SumCompensated returns the sum of fs, each converted with NewDecimal, and the
+// imprecision of that sum, which is the exact value of the floats minus sum.
+//
+// Decimal additions are exact, so no Kahan-style compensation is needed once
+// the values are decimals. The error lies in the floats themselves, which are
+// each slightly off the decimal they were meant to hold. NewDecimal recovers
+// the shortest decimal, so sum is the same as summing the original strings,
+// while adding the floats first accumulates their error. Callers who still
+// have the original strings should parse them and use SumDecimal instead.
+//
+//   e.g. [0.1 × 10]	-> 1.0, 0.000000000000000055511151231257827021181583404541015625
+//
+// It returns ErrInvalidDecimal on NaN and +/-Inf.
+func SumCompensated(fs ...float64) (sum, imprecision Decimal, err error) {
+	ds := make([]Decimal, len(fs))
+	exact := make([]Decimal, len(fs))
+	for i, f := range fs {
+		if ds[i], err = NewDecimal(f); err != nil {
+			return zero, zero, err
+		}
+		exact[i] = floatDecimal(f)
+	}
+	sum = SumDecimal(ds...)
+	return sum, SumDecimal(exact...).Sub(sum), nil
+}
+
+// floatDecimal returns the exact value of the finite f. A float64 is m·2^e, for
+// an integer m, which is m·5^-e·10^e when e is negative.
+func floatDecimal(f float64) Decimal {
+	frac, e := math.Frexp(f)
+	m := int64(frac * (1 << 53))
+	e -= 53
+
+	var d Decimal
+	d.value.SetInt64(m)
+	if e >= 0 {
+		d.value.Lsh(&d.value, uint(e))
+		return d
+	}
+	d.value.Mul(&d.value, new(big.Int).Exp(big.NewInt(5), big.NewInt(int64(-e)), nil))
+	d.exp = int32(e)
+	return d.normalize()
+}
+
+// minExponent returns the smallest exponent of ds, which must not be empty
+func minExponent(ds []Decimal) int32 {
+	exp := ds[0].exp
+	for _, d := range ds[1:] {
+		if d.exp < exp {
+			exp = d.exp
+		}
+	}
+	return exp
+}
+
+// SortDecimals sorts ds in place in ascending order.
+func SortDecimals(ds []Decimal) {
+	sort.Slice(ds, func(i, j int) bool {
+		return ds[i].Cmp(ds[j]) < 0
+	})
+}
+
 // Abs returns the absolute value of the decimal.
 func (d Decimal) Abs() Decimal {
 	d2Value := new(big.Int).Abs(&d.value)
@@ -219,6 +528,7 @@ func (d Decimal) Abs() Decimal {
 }
 
 // Add returns d + d2.
+// The result keeps the larger precision of d and d2, so no digit is lost.
 func (d Decimal) Add(d2 Decimal) Decimal {
 	baseScale := min(d.exp, d2.exp)
 	rd := d.rescale(baseScale)
@@ -231,6 +541,40 @@ func (d Decimal) Add(d2 Decimal) Decimal {
 	}
 }
 
+// SetAdd sets d to a + b and returns d. The result keeps the larger precision
+// of a and b, like Add.
+//
+// Unlike Add, SetAdd mutates d and reuses the storage of its coefficient, which
+// avoids allocations when summing in tight loops. Decimals copied from d by
+// assignment share that storage, so d must not be a copy of a decimal which is
+// still in use.
+//
+//     var sum Decimal
+//     for _, x := range values {
+//         sum.SetAdd(sum, x)
+//     }
+//
+func (d *Decimal) SetAdd(a, b Decimal) *Decimal {
+	if a.exp < b.exp {
+		a, b = b, a
+	}
+	// a has the largest exponent, so it is rescaled to b's exponent
+	exp := b.exp
+	if a.exp != b.exp {
+		scaled := &d.value
+		if sharesStorage(&d.value, &b.value) {
+			// b must be read before d is overwritten
+			scaled = new(big.Int)
+		}
+		scaled.Mul(&a.value, pow10Int(int64(a.exp)-int64(b.exp)))
+		d.value.Add(scaled, &b.value)
+	} else {
+		d.value.Add(&a.value, &b.value)
+	}
+	d.exp = exp
+	return d
+}
+
 // Sub returns d - d2.
 func (d Decimal) Sub(d2 Decimal) Decimal {
 	baseScale := min(d.exp, d2.exp)
@@ -260,10 +604,46 @@ func (d Decimal) Mul(d2 Decimal) Decimal {
 	}
 }
 
+// MulDiv returns (d * num) / den rounded to prec decimal places. The product
+// is exact, so the result is rounded only once, unlike d.Mul(num).Div(den)
+// followed by a Round. It returns ErrDivisionByZero when den is zero.
+//
+//   e.g. 100 -> f(1, 3, 2) = 33.33
+func (d Decimal) MulDiv(num, den Decimal, prec int32) (Decimal, error) {
+	if den.IsZero() {
+		return zero, ErrDivisionByZero
+	}
+	return d.Mul(num).divRound(den, prec), nil
+}
+
+// MulPow10 returns d * 10^n. It only adjusts the exponent, so unlike Mul, it
+// does not allocate a product. The result is equal to d.Mul(Pow10(n)), with the
+// precision of d shifted by n.
+//
+//   e.g. 1.25 -> f(3) = 1250
+//   e.g. 1.25 -> f(-2) = 0.0125
+func (d Decimal) MulPow10(n int32) Decimal {
+	expInt64 := int64(d.exp) + int64(n)
+	if expInt64 > math.MaxInt32 || expInt64 < math.MinInt32 {
+		panic(fmt.Sprintf("exponent %v overflows an int32!", expInt64))
+	}
+	return Decimal{
+		value: d.value,
+		exp:   int32(expInt64),
+	}
+}
+
+// MulRound returns d * d2 rounded to prec decimal places, like Round.
+// Unlike Mul, the scale of the result does not grow with the scale of the
+// operands, which keeps running products bounded.
+func (d Decimal) MulRound(d2 Decimal, prec int32) Decimal {
+	return d.Mul(d2).Round(prec)
+}
+
 // Div returns d / d2. If it doesn't divide exactly, the result will have
 // DivisionPrecision digits after the decimal point.
 func (d Decimal) Div(d2 Decimal) Decimal {
-	return d.divRound(d2, int32(divisionPrecision))
+	return d.divRound(d2, int32(GetConfig().DivisionPrecision))
 }
 
 // Neg returns -d.
@@ -281,6 +661,37 @@ func (d Decimal) Mod(d2 Decimal) Decimal {
 	return d.Sub(d2.Mul(quo))
 }
 
+// IsDivisibleBy returns whether d is a whole multiple of unit (d % unit == 0).
+// The remainder is computed with an exact integer division, so it is not
+// affected by DivisionPrecision.
+//
+// Example:
+//
+//     MustParseDecimal("1.15").IsDivisibleBy(MustParseDecimal("0.05")) // true
+//     MustParseDecimal("1.17").IsDivisibleBy(MustParseDecimal("0.05")) // false
+//
+// IsDivisibleBy panics if unit is zero.
+func (d Decimal) IsDivisibleBy(unit Decimal) bool {
+	_, r := d.quoRem(unit, 0)
+	return r.value.Sign() == SignNeutral
+}
+
+// FitsScale reports whether d has no significant digits beyond scale fraction
+// digits, so it can be stored at that scale without rounding. Trailing zeros
+// do not count.
+//
+//	e.g.:
+// 	1.2300 -> f(2) = true
+// 	1.234 -> f(2) = false
+func (d Decimal) FitsScale(scale int32) bool {
+	if int64(d.exp) >= -int64(scale) {
+		return true
+	}
+	var r big.Int
+	r.Rem(&d.value, pow10Int(-int64(scale)-int64(d.exp)))
+	return r.Sign() == SignNeutral
+}
+
 // Round rounds the decimal to places decimal places.
 // If places < 0, it will round the integer part to the nearest 10^(-places).
 //
@@ -310,6 +721,123 @@ func (d Decimal) Round(places int32) Decimal {
 	return ret
 }
 
+// WithScale returns d with exactly n fraction digits. Trailing zeros are
+// added when d has fewer digits, and d is rounded like Round when it has more.
+//
+//   e.g. 120.5 2	-> 120.50
+//   e.g. 120.567 2	-> 120.57
+func (d Decimal) WithScale(n int32) Decimal {
+	return d.Round(n)
+}
+
+// RoundE is like Round, but returns ErrInvalidPrecision instead of overflowing
+// the exponent or allocating a huge coefficient when places is far from the
+// exponent of d. It accepts places whose distance to the exponent of d is
+// within MaxDecimalDigits, or any places that do not overflow the exponent when
+// the limit is disabled.
+func (d Decimal) RoundE(places int32) (Decimal, error) {
+	if places == math.MaxInt32 || places == math.MinInt32 {
+		return zero, ErrInvalidPrecision
+	}
+	shift := int64(places) + int64(d.exp)
+	if shift < 0 {
+		shift = -shift
+	}
+	if max := GetConfig().MaxDecimalDigits; max > 0 && shift > int64(max) {
+		return zero, ErrInvalidPrecision
+	}
+	return d.Round(places), nil
+}
+
+// RoundSignificant rounds the decimal to the given number of significant
+// figures, regardless of its magnitude. Zero stays zero.
+// It panics if figs is not positive.
+//
+//	e.g.:
+// 	12345 -> f(3) = 12300
+// 	0.00012345 -> f(3) = 0.000123
+func (d Decimal) RoundSignificant(figs int32) Decimal {
+	if figs <= 0 {
+		panic(fmt.Sprintf("invalid number of significant figures %d", figs))
+	}
+	if d.value.Sign() == SignNeutral {
+		return d
+	}
+
+	m, _ := d.magnitude()
+	return d.Round(int32(int64(figs) - m - 1))
+}
+
+// siPrefixes are the SI prefixes used by SIString, by power of 1000 from 10^-9
+var siPrefixes = []string{"n", "µ", "m", "", "k", "M", "G", "T"}
+
+// SIString returns d written with an SI prefix and rounded to figs significant
+// figures, without trailing zeros. Magnitudes beyond the prefixes use the
+// closest one. It does not depend on any locale, so it is stable in logs.
+// It panics if figs is not positive.
+//
+//	e.g.:
+// 	1500000 -> f(3) = 1.5M
+// 	0.0015 -> f(3) = 1.5m
+// 	-123456 -> f(2) = -120k
+func (d Decimal) SIString(figs int32) string {
+	if figs <= 0 {
+		panic(fmt.Sprintf("invalid number of significant figures %d", figs))
+	}
+	if d.value.Sign() == SignNeutral {
+		return "0"
+	}
+
+	m, _ := d.magnitude()
+	i := m / 3
+	if m < 0 && m%3 != 0 {
+		i--
+	}
+	i += 3 // index of the empty prefix
+	if i < 0 {
+		i = 0
+	} else if i >= int64(len(siPrefixes)) {
+		i = int64(len(siPrefixes)) - 1
+	}
+
+	mantissa := d.MulPow10(int32(-(i - 3) * 3)).RoundSignificant(figs)
+	// Rounding may carry over to the next prefix (e.g. 999.9k -> 1000k)
+	if mantissa.Abs().Cmp(buildDecimal(1000, 0)) >= 0 && i < int64(len(siPrefixes))-1 {
+		i++
+		mantissa = mantissa.MulPow10(-3)
+	}
+	return mantissa.ShopspringString() + siPrefixes[i]
+}
+
+// RoundSignificantFraction rounds the fractional part of the decimal to the
+// given number of significant figures, counted from its first non-zero digit.
+// The integer part is kept, unless rounding carries over. Unlike
+// RoundSignificant, it never adds digits.
+// It panics if figs is not positive.
+//
+//	e.g.:
+// 	0.00004567 -> f(3) = 0.0000457
+// 	12.00004567 -> f(3) = 12.0000457
+func (d Decimal) RoundSignificantFraction(figs int32) Decimal {
+	if figs <= 0 {
+		panic(fmt.Sprintf("invalid number of significant figures %d", figs))
+	}
+	if d.exp >= 0 {
+		return d
+	}
+
+	frac := d.Sub(d.Truncate(0))
+	if frac.IsZero() {
+		return d
+	}
+	m, _ := frac.magnitude()
+	places := int64(figs) - m - 1
+	if places >= -int64(d.exp) {
+		return d
+	}
+	return d.Round(int32(places))
+}
+
 // RoundUp rounds the decimal up to the given precision instead of to the nearest even
 //
 //	e.g.:
@@ -347,25 +875,85 @@ func (d Decimal) RoundDown(precision int32) Decimal {
 // 	3.1416 -> f(0.05) = 3.15
 //
 func (d Decimal) RoundNearest(unit Decimal) Decimal {
-	// First round to the unit precision
-	rounded := d.Round(-unit.exp)
+	// The quotient and remainder of d itself are exact, so d is rounded once.
+	// Rounding to the unit precision first could move a value below the
+	// midpoint onto it, and Mod and Div are limited to DivisionPrecision.
+	unit = unit.Abs()
+	q, r := d.quoRem(unit, 0)
+
+	// Round away from zero from half a unit, ties away from zero
+	r = r.Abs()
+	if r.Add(r).Cmp(unit) >= 0 {
+		if d.Sign() == SignNegative {
+			q = q.Sub(one)
+		} else {
+			q = q.Add(one)
+		}
+	}
+	return q.Mul(unit)
+}
 
-	// Then move to the nearest unit
-	remainder := rounded.Mod(unit)
+// SnapTo returns the value of allowed nearest to d, with ties going to the
+// lower value. allowed must be sorted in ascending order. It returns
+// ErrNoAllowedValues when allowed is empty.
+//
+//	e.g.:
+// 	1.20 -> f([0.99, 1.49, 1.99]) = 0.99
+// 	1.24 -> f([0.99, 1.49, 1.99]) = 0.99
+// 	1.30 -> f([0.99, 1.49, 1.99]) = 1.49
+// 	5.00 -> f([0.99, 1.49, 1.99]) = 1.99
+//
+func (d Decimal) SnapTo(allowed []Decimal) (Decimal, error) {
+	if len(allowed) == 0 {
+		return zero, ErrNoAllowedValues
+	}
 
-	// Inverse signs if it is a negative decimal
-	var cmp = 1
-	if rounded.Sign() == SignNegative {
-		cmp = -1
-		unit = unit.Neg()
+	// i is the first allowed value greater than or equal to d
+	i := sort.Search(len(allowed), func(i int) bool {
+		return allowed[i].Cmp(d) >= 0
+	})
+	switch {
+	case i == 0:
+		return allowed[0], nil
+	case i == len(allowed):
+		return allowed[i-1], nil
+	}
+	lo, hi := allowed[i-1], allowed[i]
+	if d.Sub(lo).Abs().Cmp(hi.Sub(d).Abs()) <= 0 {
+		return lo, nil
 	}
+	return hi, nil
+}
 
-	// Round up
-	if remainder.Cmp(unit.Div(buildDecimal(2, 0))) == cmp {
-		return rounded.Add(unit.Sub(remainder))
+// RoundHalfDown rounds the decimal to the given precision, with ties rounded
+// toward zero.
+//
+//	e.g.:
+// 	0.125 -> f(2) = 0.12
+// 	-0.125 -> f(2) = -0.12
+// 	0.1251 -> f(2) = 0.13
+//
+func (d Decimal) RoundHalfDown(precision int32) Decimal {
+	return d.roundHalfDown(unitDecimal(-precision))
+}
+
+// roundHalfDown rounds the decimal to the nearest multiple of unit, with ties
+// rounded toward zero
+func (d Decimal) roundHalfDown(unit Decimal) Decimal {
+	q, r := d.quoRem(unit, 0)
+
+	// Compare 2 * |r| with unit
+	var rv2 big.Int
+	rv2.Abs(&r.value)
+	rv2.Lsh(&rv2, 1)
+	if (Decimal{value: rv2, exp: r.exp}).Cmp(unit.Abs()) > 0 {
+		if d.Sign() == SignNegative {
+			q = q.Sub(one)
+		} else {
+			q = q.Add(one)
+		}
 	}
-	// Round down
-	return rounded.Sub(remainder)
+	return q.Mul(unit)
 }
 
 // Truncate truncates off digits from the number, without rounding.
@@ -383,6 +971,16 @@ func (d Decimal) Truncate(precision int32) Decimal {
 	return d
 }
 
+// TruncateReport is like Truncate, but also reports whether truncation dropped
+// any non-zero digit, i.e. whether the value changed.
+//
+//   e.g. 123.456 2	-> 123.45, true
+//   e.g. 123.450 2	-> 123.45, false
+func (d Decimal) TruncateReport(precision int32) (Decimal, bool) {
+	t := d.Truncate(precision)
+	return t, t.Cmp(d) != 0
+}
+
 // Floor returns the nearest integer value less than or equal to d.
 func (d Decimal) Floor() Decimal {
 	exp := big.NewInt(10)
@@ -433,6 +1031,52 @@ func (d Decimal) Cmp(d2 Decimal) int {
 	return rd.value.Cmp(&rd2.value)
 }
 
+// SafeCmp is like Cmp, but never panics and never rescales a decimal further
+// than its number of digits, so the cost stays bounded even for exponents far
+// apart. It returns ErrInvalidDecimal when a decimal is in a corrupt state,
+// such as a magnitude beyond the int32 exponent range after a bad
+// deserialization.
+func SafeCmp(d1, d2 Decimal) (c int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			c, err = 0, ErrInvalidDecimal
+		}
+	}()
+
+	m1, ok1 := d1.magnitude()
+	m2, ok2 := d2.magnitude()
+	if !ok1 || !ok2 {
+		return 0, ErrInvalidDecimal
+	}
+
+	s1, s2 := d1.Sign(), d2.Sign()
+	switch {
+	case s1 != s2:
+		if s1 < s2 {
+			return -1, nil
+		}
+		return 1, nil
+	case s1 == SignNeutral:
+		return 0, nil
+	case m1 < m2:
+		return -s1, nil
+	case m1 > m2:
+		return s1, nil
+	}
+	// Same magnitude, so the exponents are at most a few digits apart
+	return d1.Cmp(d2), nil
+}
+
+// magnitude returns the exponent of the most significant digit of d, such as
+// 2 for 123.4. It returns false when it overflows an int32.
+func (d Decimal) magnitude() (int64, bool) {
+	m := int64(d.exp)
+	if d.value.Sign() != SignNeutral {
+		m += int64(len(new(big.Int).Abs(&d.value).String())) - 1
+	}
+	return m, m >= math.MinInt32 && m <= math.MaxInt32
+}
+
 // Equal returns whether the numbers represented by d and d2 are equal.
 func (d Decimal) Equal(d2 Decimal) bool {
 	return d.Cmp(d2) == 0
@@ -443,11 +1087,21 @@ func (d Decimal) IsZero() bool {
 	return d.Cmp(zero) == 0
 }
 
+// IsOne reports whether d represents 1, regardless of its precision
+func (d Decimal) IsOne() bool {
+	return d.Cmp(one) == 0
+}
+
+// IsHundred reports whether d represents 100, regardless of its precision
+func (d Decimal) IsHundred() bool {
+	return d.Cmp(hundred) == 0
+}
+
 // Sign returns:
 //
-//	-1 if d <  0
-//	 0 if d == 0
-//	+1 if d >  0
+//	SignNegative (-1) if d <  0
+//	SignNeutral   (0) if d == 0
+//	SignPositive (+1) if d >  0
 //
 func (d Decimal) Sign() int {
 	return d.value.Sign()
@@ -463,12 +1117,54 @@ func (d Decimal) Coefficient() big.Int {
 	return d.value
 }
 
+// Parts returns a copy of the coefficient and the exponent of the decimal,
+// such as number = coefficient * 10 ^ exp.
+// The coefficient can be modified without affecting the decimal.
+func (d Decimal) Parts() (coefficient *big.Int, exp int32) {
+	return new(big.Int).Set(&d.value), d.exp
+}
+
+// Digits returns the decimal digits of the coefficient, most significant
+// first, ignoring the sign and the exponent. Zero has a single 0 digit.
+//
+//   e.g. 123.45	-> [1, 2, 3, 4, 5]
+//   e.g. 0.00	-> [0]
+func (d Decimal) Digits() []int {
+	str := new(big.Int).Abs(&d.value).String()
+	digits := make([]int, len(str))
+	for i := 0; i < len(str); i++ {
+		digits[i] = int(str[i] - '0')
+	}
+	return digits
+}
+
 // IntPart returns the integer component of the decimal.
 func (d Decimal) IntPart() int64 {
 	scaledD := d.rescale(0)
 	return scaledD.value.Int64()
 }
 
+// ToScaledInt returns d as an integer number of 10^-scale units, rounding d
+// when it is finer than scale. It returns ErrOverflow when the result does not
+// fit in an int64.
+//
+//   e.g. 12.34 2	-> 1234
+//   e.g. 12.345 2	-> 1235
+func (d Decimal) ToScaledInt(scale int32) (int64, error) {
+	v := d.Round(scale).rescale(-scale).value
+	if !v.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return v.Int64(), nil
+}
+
+// FromScaledInt returns the decimal v * 10^-scale, the inverse of ToScaledInt.
+//
+//   e.g. 1234 2	-> 12.34
+func FromScaledInt(v int64, scale int32) Decimal {
+	return buildDecimal(v, -scale)
+}
+
 // Rat returns a rational number representation of the decimal.
 func (d Decimal) Rat() *big.Rat {
 	if d.exp <= 0 {
@@ -482,13 +1178,98 @@ func (d Decimal) Rat() *big.Rat {
 	return new(big.Rat).SetFrac(num, oneInt)
 }
 
+// RatString returns the exact value of d as a fraction in lowest terms, like
+// big.Rat.RatString, so integers have no denominator. Note that the result of
+// Div is already rounded, so it is not written as 1/3.
+//
+//   e.g. 1.23	-> 123/100
+//   e.g. 0.5	-> 1/2
+//   e.g. 120.00	-> 120
+func (d Decimal) RatString() string {
+	return d.Rat().RatString()
+}
+
 // Float64 returns the nearest float64 value for d
 func (d Decimal) Float64() float64 {
 	f, _ := d.Rat().Float64()
 	return f
 }
 
+// Float64WithAccuracy is like Float64, but also reports whether the float is
+// below, above or exactly the value of d.
+//
+//   e.g. 0.1	-> 0.1, Above
+//   e.g. 0.5	-> 0.5, Exact
+func (d Decimal) Float64WithAccuracy() (float64, Accuracy) {
+	r := d.Rat()
+	f, exact := r.Float64()
+	switch {
+	case exact:
+		return f, Exact
+	case math.IsInf(f, 0):
+		// Out of range values overflow to an infinity of the same sign
+		return f, Accuracy(d.Sign())
+	}
+	return f, Accuracy(new(big.Rat).SetFloat64(f).Cmp(r))
+}
+
 func (d Decimal) String() string {
+	return d.string(string(decSeparator))
+}
+
+// GoString implements the fmt.GoStringer interface, so %#v prints d as a
+// copy-pasteable MustParseDecimal call, such as money.MustParseDecimal("120.50").
+func (d Decimal) GoString() string {
+	return "money.MustParseDecimal(" + strconv.Quote(d.String()) + ")"
+}
+
+// StringSep is like String, but uses sep as the decimal separator.
+//
+//   e.g. MustParseDecimal("120.50").StringSep(',')	-> 120,50
+func (d Decimal) StringSep(sep rune) string {
+	return d.string(string(sep))
+}
+
+// StringTrunc is like String, but truncates (without rounding) the fractional
+// part to at most maxFrac digits. It is meant for display, such as logging the
+// result of a Div.
+//
+//   e.g. DecimalOne().Div(MustParseDecimal("3")).StringTrunc(2)	-> 0.33
+func (d Decimal) StringTrunc(maxFrac int32) string {
+	if maxFrac < 0 {
+		maxFrac = 0
+	}
+	return d.Truncate(maxFrac).String()
+}
+
+// ShopspringString returns d formatted like shopspring's Decimal.String, which
+// drops trailing fraction zeros and never ends with a radix point.
+//
+//   e.g. 120.50	-> 120.5
+//   e.g. 120.0	-> 120
+func (d Decimal) ShopspringString() string {
+	n := d.normalize()
+	if n.exp >= 0 {
+		v := n.rescale(0).value
+		return v.String()
+	}
+	return n.String()
+}
+
+// stringFixed returns d rounded to scale fraction digits, with exactly scale
+// fraction digits.
+//
+//   e.g. 1.005 2	-> 1.01
+//   e.g. 1.5 0	-> 2
+func (d Decimal) stringFixed(scale int32) string {
+	if scale <= 0 {
+		v := d.Round(0).rescale(0).value
+		return v.String()
+	}
+	return d.Round(scale).rescale(-scale).String()
+}
+
+func (d Decimal) string(sep string) string {
 	if d.exp >= 0 {
 		v := d.rescale(0).value
 		intPart := v.String()
@@ -501,7 +1282,7 @@ func (d Decimal) String() string {
 			prec = 1
 		}
 		number.WriteString(intPart)
-		number.WriteString(decSeparator)
+		number.WriteString(sep)
 		number.WriteString(strings.Repeat("0", prec))
 		return number.String()
 	}
@@ -525,7 +1306,7 @@ func (d Decimal) String() string {
 	number.WriteString(intPart)
 
 	if len(fractionalPart) > 0 {
-		number.WriteString(decSeparator)
+		number.WriteString(sep)
 		number.WriteString(fractionalPart)
 	}
 
@@ -535,28 +1316,33 @@ func (d Decimal) String() string {
 	return number.String()
 }
 
-// Formatter returns a language/currency-specific formatter for a
-// floating point decimal
-func (d *Decimal) Formatter(scale ...int) number.Formatter {
-	var s int
+// Formatter returns a language-specific formatter for d, with scale fraction
+// digits. The scale defaults to the precision of d.
+//
+// d is rounded and converted in decimal, so large values are formatted exactly
+// rather than through a float64.
+func (d *Decimal) Formatter(scale ...int) fmt.Formatter {
+	s := int32(d.roundPrec())
 	if len(scale) > 0 {
-		s = scale[0]
-	} else {
-		s = int(d.roundPrec())
+		s = int32(scale[0])
+	}
+	v := d.Round(s)
+	return localeValue{
+		digits: v.Abs().stringFixed(s),
+		neg:    v.Sign() == SignNegative,
 	}
-
-	return number.Decimal(
-		d.Float64(),
-		number.Scale(s),
-	)
 }
 
-// PercentFormatter returns a language-specific formatter for a percent
-func (d *Decimal) PercentFormatter() number.Formatter {
-	return number.Percent(
-		d.Div(hundred).Float64(),
-		number.MaxFractionDigits(int(d.roundPrec()+2)), // +2 because div by hundred
-	)
+// PercentFormatter returns a language-specific formatter for a percent. d is
+// already a percentage, so 20.5 is formatted as 20.5%. Trailing zeros are
+// dropped.
+func (d *Decimal) PercentFormatter() fmt.Formatter {
+	v := d.normalize()
+	return localeValue{
+		digits:  v.Abs().stringFixed(int32(v.roundPrec())),
+		neg:     v.Sign() == SignNegative,
+		percent: true,
+	}
 }
 
 // Validate returns whether the currency is valid
@@ -565,24 +1351,94 @@ func (d Decimal) Validate() error {
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// It accepts quoted decimals and bare JSON numbers, so it reads the output of
+// shopspring/decimal with or without MarshalJSONWithoutQuotes. Conversely,
+// MarshalJSON output is a quoted plain decimal, which shopspring accepts.
 func (d *Decimal) UnmarshalJSON(data []byte) error {
 	if len(data) > 2 && data[0] == '"' && data[len(data)-1] == '"' {
-		s := string(data[1 : len(data)-1])
-		decimal, err := ParseDecimal(s)
+		s := data[1 : len(data)-1]
+		decimal, err := ParseDecimalBytes(s)
 		if err != nil {
 			return fmt.Errorf("Error parsing money/decimal '%s': %s", s, err)
 		}
 		*d = decimal
 		return nil
 	}
+	if isJSONNumber(data) {
+		decimal, err := parseJSONNumber(data)
+		if err != nil {
+			return fmt.Errorf("Error parsing money/decimal '%s': %s", data, err)
+		}
+		*d = decimal
+		return nil
+	}
+	if !isJSONEmpty(data) {
+		return fmt.Errorf("Error parsing money/decimal '%s': %s", data, ErrInvalidDecimal)
+	}
 
 	// Accept empty data. The Validate function should be used to make sure it
 	// is valid
 	return nil
 }
 
+// isJSONEmpty returns whether data is null or an empty string
+func isJSONEmpty(data []byte) bool {
+	s := string(data)
+	return s == "" || s == "null" || s == `""`
+}
+
+// isJSONNumber returns whether data is a bare JSON number, as opposed to a
+// string or null
+func isJSONNumber(data []byte) bool {
+	return len(data) > 0 && (data[0] == '-' || ('0' <= data[0] && data[0] <= '9'))
+}
+
+// parseJSONNumber parses a bare JSON number. Plain numbers keep their textual
+// precision, and exponent notation is applied exactly to the exponent.
+//
+//   e.g. 120.50	-> 120.50
+//   e.g. 1.205e2	-> 120.5
+//
+// The digits of the coefficient plus the absolute exponent count towards
+// MaxDecimalDigits, since rescaling 1e100000000 allocates as many digits as a
+// coefficient of that length.
+func parseJSONNumber(data []byte) (Decimal, error) {
+	i := bytes.IndexAny(data, "eE")
+	if i < 0 {
+		return ParseDecimalBytes(data)
+	}
+
+	d, err := ParseDecimalBytes(data[:i])
+	if err != nil {
+		return Decimal{}, err
+	}
+	e, err := strconv.ParseInt(string(data[i+1:]), 10, 32)
+	if err != nil {
+		return Decimal{}, ErrInvalidDecimal
+	}
+	e += int64(d.exp)
+	if e < math.MinInt32 || e > math.MaxInt32 {
+		return Decimal{}, ErrInvalidDecimal
+	}
+	if max := GetConfig().MaxDecimalDigits; max > 0 {
+		abs := e
+		if abs < 0 {
+			abs = -abs
+		}
+		if abs+int64(len(new(big.Int).Abs(&d.value).String())) > int64(max) {
+			return Decimal{}, ErrInvalidDecimal
+		}
+	}
+	d.exp = int32(e)
+	return d, nil
+}
+
 // MarshalJSON implements the json.Marshaler interface.
 func (d Decimal) MarshalJSON() ([]byte, error) {
+	if GetConfig().MarshalJSONWithoutQuotes {
+		return []byte(d.String()), nil
+	}
 	return []byte("\"" + d.String() + "\""), nil
 }
 
@@ -614,15 +1470,162 @@ func (d Decimal) MarshalBinary() (data []byte, err error) {
 	return data, err
 }
 
+// BinarySize returns the number of bytes MarshalBinary produces for d, without
+// encoding it.
+func (d Decimal) BinarySize() int {
+	// 4 bytes exponent + 1 byte version/sign + absolute value bytes
+	return 4 + 1 + (d.value.BitLen()+7)/8
+}
+
+// UnmarshalBinaryV2 decodes data produced by MarshalBinaryV2.
+func (d *Decimal) UnmarshalBinaryV2(data []byte) error {
+	// Extract the exponent
+	exp, n := binary.Varint(data)
+	if n <= 0 || exp < math.MinInt32 || exp > math.MaxInt32 {
+		return ErrInvalidDecimal
+	}
+	data = data[n:]
+
+	// Extract the sign and the value
+	if len(data) == 0 {
+		return ErrInvalidDecimal
+	}
+	value := new(big.Int).SetBytes(data[1:])
+	switch data[0] {
+	case 0:
+	case 1:
+		value.Neg(value)
+	default:
+		return ErrInvalidDecimal
+	}
+
+	d.value = *value
+	d.exp = int32(exp)
+	return nil
+}
+
+// MarshalBinaryV2 is a more compact alternative to MarshalBinary.
+//
+// The exponent is written first as a varint, followed by a sign byte (0 for
+// positive or zero, 1 for negative) and the big-endian bytes of the absolute
+// value.
+func (d Decimal) MarshalBinaryV2() ([]byte, error) {
+	value := d.value.Bytes()
+	data := make([]byte, binary.MaxVarintLen32, binary.MaxVarintLen32+1+len(value))
+	n := binary.PutVarint(data, int64(d.exp))
+	data = data[:n]
+
+	if d.value.Sign() == SignNegative {
+		data = append(data, 1)
+	} else {
+		data = append(data, 0)
+	}
+	return append(data, value...), nil
+}
+
+// Tags of the OrderedBytes encoding
+const (
+	orderedNegative byte = 0x01
+	orderedZero     byte = 0x02
+	orderedPositive byte = 0x03
+)
+
+// OrderedBytes returns an encoding of d where bytes.Compare matches Cmp, such
+// as a key for range scans in a key-value store. Decimals which are Equal have
+// the same encoding, so the precision of d is not kept.
+//
+// The encoding starts with a sign tag (negative, zero or positive). Non-zero
+// decimals follow with the magnitude of their most significant digit, as an 8
+// bytes big-endian integer with its sign bit flipped, then their significant
+// digits in ASCII and a 0x00 terminator. All bytes after the tag are inverted
+// for negative decimals, so larger magnitudes sort first.
+//
+//   e.g. 120.50	-> 03 80000000 00000002 '1' '2' '0' '5' 00
+func (d Decimal) OrderedBytes() []byte {
+	if d.value.Sign() == SignNeutral {
+		return []byte{orderedZero}
+	}
+
+	n := d.normalize()
+	digits := new(big.Int).Abs(&n.value).String()
+	m := int64(n.exp) + int64(len(digits)) - 1
+
+	data := make([]byte, 1+8, 1+8+len(digits)+1)
+	data[0] = orderedPositive
+	binary.BigEndian.PutUint64(data[1:], uint64(m)^(1<<63))
+	data = append(data, digits...)
+	data = append(data, 0x00)
+
+	if n.value.Sign() == SignNegative {
+		data[0] = orderedNegative
+		for i := 1; i < len(data); i++ {
+			data[i] = ^data[i]
+		}
+	}
+	return data
+}
+
+// DecimalFromOrderedBytes decodes a Decimal encoded by OrderedBytes. It returns
+// ErrInvalidDecimal when data is not a valid encoding.
+func DecimalFromOrderedBytes(data []byte) (Decimal, error) {
+	if len(data) == 0 {
+		return zero, ErrInvalidDecimal
+	}
+	switch data[0] {
+	case orderedZero:
+		if len(data) != 1 {
+			return zero, ErrInvalidDecimal
+		}
+		return Decimal{}, nil
+	case orderedPositive, orderedNegative:
+	default:
+		return zero, ErrInvalidDecimal
+	}
+	if len(data) < 1+8+2 {
+		return zero, ErrInvalidDecimal
+	}
+
+	neg := data[0] == orderedNegative
+	body := make([]byte, len(data)-1)
+	copy(body, data[1:])
+	if neg {
+		for i := range body {
+			body[i] = ^body[i]
+		}
+	}
+
+	m := int64(binary.BigEndian.Uint64(body) ^ (1 << 63))
+	digits := body[8 : len(body)-1]
+	if body[len(body)-1] != 0x00 || digits[0] == '0' {
+		return zero, ErrInvalidDecimal
+	}
+	exp := m - int64(len(digits)) + 1
+	if exp < math.MinInt32 || exp > math.MaxInt32 {
+		return zero, ErrInvalidDecimal
+	}
+
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return zero, ErrInvalidDecimal
+		}
+	}
+
+	var d Decimal
+	d.value.SetString(string(digits), 10)
+	if neg {
+		d.value.Neg(&d.value)
+	}
+	d.exp = int32(exp)
+	return d, nil
+}
+
 // UnmarshalText implements the encoding.TextUnmarshaler interface for XML
 // deserialization.
 func (d *Decimal) UnmarshalText(text []byte) error {
-	str := string(text)
-
-	dec, err := ParseDecimal(str)
+	dec, err := ParseDecimalBytes(text)
 	*d = dec
 	if err != nil {
-		return fmt.Errorf("Error decoding string '%s': %s", str, err)
+		return fmt.Errorf("Error decoding string '%s': %s", text, err)
 	}
 
 	return nil
@@ -634,6 +1637,54 @@ func (d Decimal) MarshalText() (text []byte, err error) {
 	return []byte(d.String()), nil
 }
 
+// Scan implements the sql.Scanner interface for database deserialization.
+func (d *Decimal) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		dec, err := ParseDecimalBytes(v)
+		if err != nil {
+			return err
+		}
+		*d = dec
+	case string:
+		dec, err := ParseDecimal(v)
+		if err != nil {
+			return err
+		}
+		*d = dec
+	case int64:
+		*d = buildDecimal(v, 0)
+	case float64:
+		dec, err := NewDecimal(v)
+		if err != nil {
+			return err
+		}
+		*d = dec
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidDecimal, value)
+	}
+	return nil
+}
+
+// ScanDecimals parses a batch of raw column values into dest, as loaded in
+// bulk from a numeric column. It is a faster alternative to calling Scan on
+// each row. dest and src must have the same length.
+// On a malformed value, it returns an error wrapping ErrInvalidDecimal with
+// its index, and the following values are left untouched.
+func ScanDecimals(dest []*Decimal, src [][]byte) error {
+	if len(dest) != len(src) {
+		return ErrLengthMismatch
+	}
+	for i, b := range src {
+		dec, err := ParseDecimalBytes(b)
+		if err != nil {
+			return fmt.Errorf("%w: cell %d %q", err, i, b)
+		}
+		*dest[i] = dec
+	}
+	return nil
+}
+
 // GobEncode implements the gob.GobEncoder interface for gob serialization.
 func (d Decimal) GobEncode() ([]byte, error) {
 	return d.MarshalBinary()
@@ -665,34 +1716,245 @@ func buildDecimal(value int64, exp int32) Decimal {
 	}
 }
 
+// sharesStorage returns whether x and y are backed by the same array
+func sharesStorage(x, y *big.Int) bool {
+	xb, yb := x.Bits(), y.Bits()
+	if cap(xb) == 0 || cap(yb) == 0 {
+		return false
+	}
+	return &xb[:cap(xb)][cap(xb)-1] == &yb[:cap(yb)][cap(yb)-1]
+}
+
+// pow10Int returns 10 ^ n. Small powers are shared, so the result must only be
+// used as a read-only operand.
+func pow10Int(n int64) *big.Int {
+	if n < int64(len(pow10Ints)) {
+		return pow10Ints[n]
+	}
+	return new(big.Int).Exp(tenInt, big.NewInt(n), nil)
+}
+
+// unitDecimal returns 1 * 10 ^ exp. The coefficient is shared with one, so the
+// result must only be used as a read-only operand, and never returned to
+// callers.
+func unitDecimal(exp int32) Decimal {
+	return Decimal{
+		value: one.value,
+		exp:   exp,
+	}
+}
+
 // Pow10 returns 10**d, the base-10 exponential of d.
+//
+// It panics if d is an integer which does not fit in an int64, since such a
+// power cannot be represented.
 func Pow10(d Decimal) Decimal {
-	return ten.Pow(d)
+	// 10 is positive, so Pow can only fail on an integer overflow
+	p, err := ten.Pow(d)
+	if err != nil {
+		panic(err)
+	}
+	return p
 }
 
-// Pow returns d to the power d2
-func (d Decimal) Pow(d2 Decimal) Decimal {
-	var temp Decimal
-	if d2.IntPart() == 0 {
-		x, err := NewDecimal(1)
-		if err != nil {
-			panic(err)
+// Pow returns d to the power d2.
+//
+// Integer exponents are computed exactly with PowInt. Fractional exponents are
+// computed as exp(d2 * ln(d)) and rounded to DivisionPrecision digits after
+// the decimal point.
+//
+//   e.g. 2 -> f(0.5) = 1.4142135623730950
+//
+// Pow returns ErrNegativeBase if d is negative and d2 is fractional, since the
+// result would be a complex number, and ErrOverflow if d2 is an integer which
+// does not fit in an int64. It panics if d is zero and d2 is negative.
+func (d Decimal) Pow(d2 Decimal) (Decimal, error) {
+	if d2.IsDivisibleBy(one) {
+		n := d2.rescale(0)
+		if !n.value.IsInt64() {
+			return zero, ErrOverflow
 		}
-		return x
+		return d.PowInt(n.value.Int64()), nil
 	}
 
-	x, err := NewDecimal(2)
-	if err != nil {
-		panic(err)
+	switch d.Sign() {
+	case SignNegative:
+		return zero, ErrNegativeBase
+	case SignNeutral:
+		if d2.Sign() == SignNegative {
+			panic("decimal division by 0")
+		}
+		return zero, nil
 	}
-	temp = d.Pow(d2.Div(x))
-	if d2.IntPart()%2 == 0 {
-		return temp.Mul(temp)
+
+	prec := int32(GetConfig().DivisionPrecision)
+
+	// The result has as many integer digits as d2 * ln(d) / ln(10), and each of
+	// them must be matched by a digit of precision on the logarithm.
+	y := d2.Mul(d.natLog(8))
+	guard := int32(y.Abs().IntPart()/2) + int32(len(strconv.FormatInt(d2.Abs().IntPart(), 10))) + 4
+	y = d2.Mul(d.natLog(prec + guard))
+	return y.natExp(prec), nil
+}
+
+// natExp returns e^d rounded to prec digits after the decimal point
+func (d Decimal) natExp(prec int32) Decimal {
+	if d.Sign() == SignNegative {
+		return one.divRound(d.Neg().natExp(prec+2), prec)
+	}
+
+	// Reduce the argument below 1, so e^d = (e^(d/2^k))^(2^k)
+	var k int32
+	r := d
+	for r.Cmp(one) >= 0 {
+		r = r.Mul(half)
+		k++
 	}
-	if d2.IntPart() > 0 {
-		return temp.Mul(temp).Mul(d)
+
+	// Each squaring doubles the relative error and the result has up to d/2
+	// integer digits
+	wp := prec + k + int32(d.IntPart()/2) + 10
+	r = r.Round(wp)
+
+	// Taylor series: sum(r^n / n!)
+	sum := buildDecimal(1, 0)
+	term := one
+	for n := int64(1); ; n++ {
+		term = term.Mul(r).divRound(buildDecimal(n, 0), wp)
+		if term.IsZero() {
+			break
+		}
+		sum = sum.Add(term)
+	}
+
+	for ; k > 0; k-- {
+		sum = sum.Mul(sum).Round(wp)
 	}
-	return temp.Mul(temp).Div(d)
+	return sum.Round(prec)
+}
+
+// natLog returns the natural logarithm of d rounded to prec digits after the
+// decimal point. d must be positive.
+func (d Decimal) natLog(prec int32) Decimal {
+	wp := prec + 10
+
+	// Reduce the argument to [1, 2), so ln(d) = ln(m) + e * ln(2)
+	var e int64
+	m := d
+	for m.Cmp(two) >= 0 {
+		m = m.Mul(half).Round(wp)
+		e++
+	}
+	for m.Cmp(one) < 0 {
+		m = m.Mul(two)
+		e--
+	}
+
+	res := atanh(m.Sub(one).divRound(m.Add(one), wp), wp).Mul(two)
+	if e != 0 {
+		ln2 := atanh(one.divRound(buildDecimal(3, 0), wp), wp).Mul(two)
+		res = res.Add(ln2.Mul(buildDecimal(e, 0)))
+	}
+	return res.Round(prec)
+}
+
+// atanh returns the inverse hyperbolic tangent of z, which must be within
+// (-1, 1), rounded to prec digits after the decimal point.
+//
+// ln(x) = 2 * atanh((x - 1) / (x + 1))
+func atanh(z Decimal, prec int32) Decimal {
+	// Series: sum(z^(2n+1) / (2n+1))
+	z2 := z.Mul(z).Round(prec)
+	sum := z
+	term := z
+	for n := int64(3); ; n += 2 {
+		term = term.Mul(z2).Round(prec)
+		t := term.divRound(buildDecimal(n, 0), prec)
+		if t.IsZero() {
+			break
+		}
+		sum = sum.Add(t)
+	}
+	return sum.Round(prec)
+}
+
+// Cbrt returns the cube root of d rounded to DivisionPrecision digits after the
+// decimal point. Negative decimals have a negative cube root, so unlike
+// fractional powers, it never fails and the error is always nil.
+//
+//   e.g. 27	-> 3
+//   e.g. -8	-> -2
+//   e.g. 2	-> 1.2599210498948732
+func (d Decimal) Cbrt() (Decimal, error) {
+	if d.value.Sign() == SignNeutral {
+		return Decimal{}, nil
+	}
+
+	// cbrt(v * 10^e) * 10^p = cbrt(v * 10^(e + 3p)), where p is large enough
+	// for e + 3p to be positive and leaves a guard digit for rounding
+	prec := int64(GetConfig().DivisionPrecision) + 1
+	if p := (-int64(d.exp) + 2) / 3; p > prec {
+		prec = p
+	}
+	var n big.Int
+	n.Abs(&d.value)
+	n.Mul(&n, pow10Int(int64(d.exp)+3*prec))
+
+	root := Decimal{value: *icbrt(&n), exp: int32(-prec)}
+	if d.value.Sign() == SignNegative {
+		root = root.Neg()
+	}
+	return root.Round(int32(GetConfig().DivisionPrecision)), nil
+}
+
+// icbrt returns the integer cube root of n, i.e. the largest x such that
+// x^3 <= n. n must be positive.
+func icbrt(n *big.Int) *big.Int {
+	// Start above the root, so Newton's iteration decreases monotonically
+	x := new(big.Int).Lsh(oneInt, uint(n.BitLen()+2)/3)
+	var y, x2 big.Int
+	for {
+		// y = (2x + n / x^2) / 3
+		x2.Mul(x, x)
+		y.Quo(n, &x2)
+		y.Add(&y, x)
+		y.Add(&y, x)
+		y.Quo(&y, big.NewInt(3))
+		if y.Cmp(x) >= 0 {
+			return x
+		}
+		x.Set(&y)
+	}
+}
+
+// PowInt returns d to the power n. The result is exact when n >= 0. When n is
+// negative, the result is computed as 1 / d^-n and rounded like Div.
+//
+//   e.g. 1.05 -> f(3) = 1.157625
+//
+// PowInt panics if d is zero and n is negative.
+func (d Decimal) PowInt(n int64) Decimal {
+	if n < 0 {
+		// -n overflows for math.MinInt64, whereas its uint64 negation does not
+		return one.Div(d.powUint(-uint64(n)))
+	}
+	return d.powUint(uint64(n))
+}
+
+// powUint returns d to the power n, computed exactly by squaring
+func (d Decimal) powUint(n uint64) Decimal {
+	res := buildDecimal(1, 0)
+	base := d
+	for n > 0 {
+		if n&1 == 1 {
+			res = res.Mul(base)
+		}
+		n >>= 1
+		if n > 0 {
+			base = base.Mul(base)
+		}
+	}
+	return res
 }
 
 // divRound divides and rounds to a given precision
@@ -717,11 +1979,11 @@ func (d Decimal) divRound(d2 Decimal, precision int32) Decimal {
 		return q
 	}
 
-	if d.value.Sign()*d2.value.Sign() < SignNegative {
-		return q.Sub(buildDecimal(1, -precision))
+	if d.value.Sign()*d2.value.Sign() == SignNegative {
+		return q.Sub(unitDecimal(-precision))
 	}
 
-	return q.Add(buildDecimal(1, -precision))
+	return q.Add(unitDecimal(-precision))
 }
 
 // quoRem does divsion with remainder
@@ -805,6 +2067,27 @@ func (d Decimal) rescale(exp int32) Decimal {
 	}
 }
 
+// normalize returns d without trailing zeros in its coefficient, such that two
+// Equal decimals have the same coefficient and exponent. Zero has a 0
+// exponent.
+func (d Decimal) normalize() Decimal {
+	if d.value.Sign() == SignNeutral {
+		return Decimal{}
+	}
+
+	var q, r big.Int
+	value := new(big.Int).Set(&d.value)
+	exp := d.exp
+	for {
+		q.QuoRem(value, tenInt, &r)
+		if r.Sign() != SignNeutral {
+			return Decimal{value: *value, exp: exp}
+		}
+		value.Set(&q)
+		exp++
+	}
+}
+
 func (d *Decimal) roundPrec() uint {
 	if d.exp < 0 {
 		return uint(d.exp * -1)
@@ -1,6 +1,17 @@
 package money_test
 
 import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/deixis/money"
@@ -13,55 +24,233 @@ type decPair struct {
 	Y string
 }
 
+var parseDecimalTests = []struct {
+	input  string
+	expect float64
+	err    error
+}{
+	{input: "120.0", expect: 120},
+	{input: "120.00", expect: 120},
+	{input: "120.000", expect: 120},
+	{input: "120.12", expect: 120.12},
+	{input: "120.125", expect: 120.125},
+	{input: "120.123456789", expect: 120.123456789},
+	{input: "0.00000001", expect: 0.00000001},           // 1 satoshi
+	{input: "17950000000000.0", expect: 17950000000000}, // GDP USA
+	{input: "3.141592653589793", expect: 3.141592653589793},
+	{input: ".1111111111111110", expect: 0.1111111111111110},
+	{input: ".1111111111111111", expect: 0.1111111111111111},
+	{input: ".1111111111111119", expect: 0.1111111111111119},
+	{input: "0.001", expect: 0.001},
+	{input: "0.002", expect: 0.002},
+	{input: "0.003", expect: 0.003},
+	{input: "0.004", expect: 0.004},
+	{input: "0.005", expect: 0.005},
+	{input: "0.006", expect: 0.006},
+	{input: "0.007", expect: 0.007},
+	{input: "0.008", expect: 0.008},
+	{input: "0.009", expect: 0.009},
+	{input: "0.00", expect: 0},
+	{input: "-0.00", expect: -0},
+	{input: "-1.00", expect: -1.0},
+	{input: "0", expect: 0},
+	{input: "120", expect: 120},
+	{input: "yyy", err: money.ErrInvalidDecimal},
+	{input: "yyy.yyy", err: money.ErrInvalidDecimal},
+	{input: "0x1.fffffffffffffp1023", err: money.ErrInvalidDecimal},
+	{input: "123456789012345678901234567890.5", expect: 123456789012345678901234567890.5},
+	{input: "-0.1234567890123456789012345", expect: -0.1234567890123456789012345},
+	{input: "+1.5", expect: 1.5},
+	{input: "1.", expect: 1},
+	{input: "", err: money.ErrInvalidDecimal},
+	{input: ".", err: money.ErrInvalidDecimal},
+	{input: "-", err: money.ErrInvalidDecimal},
+	{input: ".-5", err: money.ErrInvalidDecimal},
+	{input: "1-2", err: money.ErrInvalidDecimal},
+	{input: "1.2.3", err: money.ErrInvalidDecimal},
+	{input: "1e3", err: money.ErrInvalidDecimal},
+	{input: "١٢٣", err: money.ErrInvalidDecimal},
+}
+
 func TestParseDecimal(t *testing.T) {
 	t.Parallel()
 
+	for i, test := range parseDecimalTests {
+		dec, err := money.ParseDecimal(test.input)
+		if err != nil {
+			if test.err != err {
+				t.Errorf("#%d - expect error %s, but got %s - %s", i, test.err, err, test.input)
+			}
+			continue
+		}
+		if test.expect != dec.Float64() {
+			t.Errorf("#%d - expect %f, but got %f - %s", i, test.expect, dec.Float64(), test.input)
+		}
+	}
+}
+
+func TestDecimal_SetString(t *testing.T) {
+	t.Parallel()
+
+	// A single receiver is reused across all parses
+	var d money.Decimal
+	for i, test := range parseDecimalTests {
+		err := d.SetString(test.input)
+		expect, expectErr := money.ParseDecimal(test.input)
+		if expectErr != err {
+			t.Errorf("#%d - expect error %v, but got %v - %s", i, expectErr, err, test.input)
+			continue
+		}
+		if err != nil {
+			if !d.IsZero() {
+				t.Errorf("#%d - expect zero on error, but got %s - %s", i, d, test.input)
+			}
+			continue
+		}
+		if expect.String() != d.String() || expect.Exponent() != d.Exponent() {
+			t.Errorf("#%d - expect %s, but got %s - %s", i, expect, d, test.input)
+		}
+	}
+}
+
+func TestDecimal_SetString_Copies(t *testing.T) {
+	t.Parallel()
+
+	// Earlier copies of a reused receiver keep their own value
+	var x money.Decimal
+	var list []money.Decimal
+	for _, s := range []string{"1", "2", "3"} {
+		if err := x.SetString(s); err != nil {
+			t.Fatalf("expect no error, but got %s - %s", err, s)
+		}
+		list = append(list, x)
+	}
+	for i, expect := range []string{"1", "2", "3"} {
+		if !money.MustParseDecimal(expect).Equal(list[i]) {
+			t.Errorf("#%d - expect %s, but got %s", i, expect, list[i])
+		}
+	}
+	m := money.NewMoney(x, "CHF")
+	if err := x.SetString("4"); err != nil {
+		t.Fatalf("expect no error, but got %s", err)
+	}
+	if err := x.SetString("bad"); err == nil {
+		t.Fatal("expect an error, but got nil")
+	}
+	if !money.MustParseDecimal("3").Equal(m.Amount) {
+		t.Errorf("expect amount 3, but got %s", m.Amount)
+	}
+
+	// Decimals returned by the package never share its own values
+	table := []struct {
+		input  func() money.Decimal
+		expect string
+	}{
+		{input: money.DecimalOne, expect: "1"},
+		{input: money.DecimalHundred, expect: "100"},
+		{input: func() money.Decimal { return money.MustParseDecimal("2").PowInt(0) }, expect: "1"},
+		{input: func() money.Decimal {
+			money.RegisterUnoficialCurrencyScale("XSTR", 3)
+			return money.MustParseCurrency("XSTR").RoundUnit(money.RoundingStandard)
+		}, expect: "0.001"},
+	}
+	for i, test := range table {
+		d := test.input()
+		if err := d.SetString("7"); err != nil {
+			t.Fatalf("#%d - expect no error, but got %s", i, err)
+		}
+		if res := test.input(); !money.MustParseDecimal(test.expect).Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, res)
+		}
+		if res := money.MustParseDecimal("1.5").Round(0); !money.MustParseDecimal("2").Equal(res) {
+			t.Errorf("#%d - expect 1.5 to round to 2, but got %s", i, res)
+		}
+		if res := money.MustParseDecimal("5").PowInt(0); !res.IsOne() {
+			t.Errorf("#%d - expect 1, but got %s", i, res)
+		}
+	}
+}
+
+func TestParseDecimalBytes(t *testing.T) {
+	t.Parallel()
+
+	for i, test := range parseDecimalTests {
+		dec, err := money.ParseDecimalBytes([]byte(test.input))
+		if err != nil {
+			if test.err != err {
+				t.Errorf("#%d - expect error %s, but got %s - %s", i, test.err, err, test.input)
+			}
+			continue
+		}
+		if test.err != nil {
+			t.Errorf("#%d - expect error %s, but got nil - %s", i, test.err, test.input)
+			continue
+		}
+		if test.expect != dec.Float64() {
+			t.Errorf("#%d - expect %f, but got %f - %s", i, test.expect, dec.Float64(), test.input)
+		}
+		if str := money.MustParseDecimal(test.input); str.String() != dec.String() {
+			t.Errorf("#%d - expect %s, but got %s", i, str, dec)
+		}
+	}
+}
+
+func FuzzParseDecimal(f *testing.F) {
+	for _, test := range parseDecimalTests {
+		f.Add(test.input)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		dec, err := money.ParseDecimal(input)
+		if err != nil {
+			return
+		}
+
+		str := dec.String()
+		back, err := money.ParseDecimal(str)
+		if err != nil {
+			t.Fatalf("cannot parse %q formatted from %q: %s", str, input, err)
+		}
+		if !dec.Equal(back) {
+			t.Fatalf("expect %s, but got %s - %q", dec, back, input)
+		}
+		if back.String() != str {
+			t.Fatalf("expect %s, but got %s - %q", str, back, input)
+		}
+	})
+}
+
+func TestParseDecimalSep(t *testing.T) {
+	t.Parallel()
+
 	table := []struct {
 		input  string
-		expect float64
+		expect string
 		err    error
 	}{
-		{input: "120.0", expect: 120},
-		{input: "120.00", expect: 120},
-		{input: "120.000", expect: 120},
-		{input: "120.12", expect: 120.12},
-		{input: "120.125", expect: 120.125},
-		{input: "120.123456789", expect: 120.123456789},
-		{input: "0.00000001", expect: 0.00000001},           // 1 satoshi
-		{input: "17950000000000.0", expect: 17950000000000}, // GDP USA
-		{input: "3.141592653589793", expect: 3.141592653589793},
-		{input: ".1111111111111110", expect: 0.1111111111111110},
-		{input: ".1111111111111111", expect: 0.1111111111111111},
-		{input: ".1111111111111119", expect: 0.1111111111111119},
-		{input: "0.001", expect: 0.001},
-		{input: "0.002", expect: 0.002},
-		{input: "0.003", expect: 0.003},
-		{input: "0.004", expect: 0.004},
-		{input: "0.005", expect: 0.005},
-		{input: "0.006", expect: 0.006},
-		{input: "0.007", expect: 0.007},
-		{input: "0.008", expect: 0.008},
-		{input: "0.009", expect: 0.009},
-		{input: "0.00", expect: 0},
-		{input: "-0.00", expect: -0},
-		{input: "-1.00", expect: -1.0},
-		{input: "0", expect: 0},
-		{input: "120", expect: 120},
-		{input: "yyy", err: money.ErrInvalidDecimal},
-		{input: "yyy.yyy", err: money.ErrInvalidDecimal},
-		{input: "0x1.fffffffffffffp1023", err: money.ErrInvalidDecimal},
+		{input: "120,0", expect: "120.0"},
+		{input: "120,50", expect: "120.50"},
+		{input: "-0,00000001", expect: "-0.00000001"},
+		{input: ",5", expect: "0.5"},
+		{input: "120", expect: "120.0"},
+		{input: "120.50", err: money.ErrInvalidDecimal},
+		{input: "1,000,50", err: money.ErrInvalidDecimal},
 	}
 
 	for i, test := range table {
-		dec, err := money.ParseDecimal(test.input)
+		dec, err := money.ParseDecimalSep(test.input, ',')
 		if err != nil {
 			if test.err != err {
 				t.Errorf("#%d - expect error %s, but got %s - %s", i, test.err, err, test.input)
 			}
 			continue
 		}
-		if test.expect != dec.Float64() {
-			t.Errorf("#%d - expect %f, but got %f - %s", i, test.expect, dec.Float64(), test.input)
+		if test.err != nil {
+			t.Errorf("#%d - expect error %s, but got nil - %s", i, test.err, test.input)
+			continue
+		}
+		if test.expect != dec.String() {
+			t.Errorf("#%d - expect %s, but got %s - %s", i, test.expect, dec, test.input)
 		}
 	}
 }
@@ -94,6 +283,149 @@ func TestNewDecimal(t *testing.T) {
 	}
 }
 
+func TestNewDecimal_NonFinite(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input float64
+	}{
+		{input: math.NaN()},
+		{input: math.Inf(1)},
+		{input: math.Inf(-1)},
+	}
+
+	for i, test := range table {
+		dec, err := money.NewDecimal(test.input)
+		if err != money.ErrInvalidDecimal {
+			t.Errorf("#%d - expect error %s, but got %v - %f", i, money.ErrInvalidDecimal, err, test.input)
+		}
+		if !dec.IsZero() {
+			t.Errorf("#%d - expect zero, but got %s - %f", i, dec, test.input)
+		}
+		if err := money.NewMoney(dec, "CHF").Validate(); err != nil {
+			t.Errorf("#%d - expect the returned zero to be valid, but got %s - %f", i, err, test.input)
+		}
+	}
+
+	// Integral floats at the int64 boundaries
+	for i, input := range []float64{math.MaxInt64, math.MinInt64, 1e19, -1e19} {
+		dec, err := money.NewDecimal(input)
+		if err != nil {
+			t.Fatalf("#%d - expect no error, but got %s - %f", i, err, input)
+		}
+		if res := dec.Float64(); input != res {
+			t.Errorf("#%d - expect %f, but got %f", i, input, res)
+		}
+	}
+}
+
+func TestNewFromNumber(t *testing.T) {
+	t.Parallel()
+
+	type cents int64
+	type rate float32
+	type amount float64
+
+	table := []struct {
+		fn     func() (money.Decimal, error)
+		expect string
+		err    error
+	}{
+		{fn: func() (money.Decimal, error) { return money.NewFromNumber(120) }, expect: "120"},
+		{fn: func() (money.Decimal, error) { return money.NewFromNumber(-120) }, expect: "-120"},
+		{fn: func() (money.Decimal, error) { return money.NewFromNumber(int64(math.MaxInt64)) }, expect: "9223372036854775807"},
+		{fn: func() (money.Decimal, error) { return money.NewFromNumber(int64(math.MinInt64)) }, expect: "-9223372036854775808"},
+		{fn: func() (money.Decimal, error) { return money.NewFromNumber(uint32(math.MaxUint32)) }, expect: "4294967295"},
+		{fn: func() (money.Decimal, error) { return money.NewFromNumber(uint64(math.MaxUint64)) }, expect: "18446744073709551615"},
+		{fn: func() (money.Decimal, error) { return money.NewFromNumber(int8(-8)) }, expect: "-8"},
+		{fn: func() (money.Decimal, error) { return money.NewFromNumber(cents(1250)) }, expect: "1250"},
+		{fn: func() (money.Decimal, error) { return money.NewFromNumber(120.125) }, expect: "120.125"},
+		{fn: func() (money.Decimal, error) { return money.NewFromNumber(0.1) }, expect: "0.1"},
+		{fn: func() (money.Decimal, error) { return money.NewFromNumber(float32(0.1)) }, expect: "0.1"},
+		{fn: func() (money.Decimal, error) { return money.NewFromNumber(float32(-2.5)) }, expect: "-2.5"},
+		{fn: func() (money.Decimal, error) { return money.NewFromNumber(rate(0.1)) }, expect: "0.1"},
+		{fn: func() (money.Decimal, error) { return money.NewFromNumber(amount(float32(0.1))) }, expect: "0.10000000149011612"},
+		{fn: func() (money.Decimal, error) { return money.NewFromNumber(math.NaN()) }, err: money.ErrInvalidDecimal},
+		{fn: func() (money.Decimal, error) { return money.NewFromNumber(rate(math.NaN())) }, err: money.ErrInvalidDecimal},
+		{fn: func() (money.Decimal, error) { return money.NewFromNumber(float32(math.Inf(1))) }, err: money.ErrInvalidDecimal},
+	}
+
+	for i, test := range table {
+		res, err := test.fn()
+		if test.err != err {
+			t.Errorf("#%d - expect error %v, but got %v", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if expect := money.MustParseDecimal(test.expect); !expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, expect, res)
+		}
+	}
+}
+
+func TestNewDecimalExact(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  float64
+		expect string
+		err    error
+	}{
+		{input: 0.5, expect: "0.5"},
+		{input: 0.25, expect: "0.25"},
+		{input: -120.125, expect: "-120.125"},
+		{input: 120, expect: "120"},
+		{input: 0, expect: "0"},
+		{input: 0.1, err: money.ErrInexactFloat},
+		{input: 120.12, err: money.ErrInexactFloat},
+		{input: 0.00000001, err: money.ErrInexactFloat},
+	}
+
+	for i, test := range table {
+		dec, err := money.NewDecimalExact(test.input)
+		if test.err != err {
+			t.Errorf("#%d - expect error %s, but got %s - %f", i, test.err, err, test.input)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !money.MustParseDecimal(test.expect).Equal(dec) {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, dec)
+		}
+	}
+}
+
+func TestDecimal_Float64WithAccuracy(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input    string
+		expect   float64
+		accuracy money.Accuracy
+	}{
+		{input: "0.5", expect: 0.5, accuracy: money.Exact},
+		{input: "0.125", expect: 0.125, accuracy: money.Exact},
+		{input: "120", expect: 120, accuracy: money.Exact},
+		{input: "0.1", expect: 0.1, accuracy: money.Above},
+		{input: "-0.1", expect: -0.1, accuracy: money.Below},
+		{input: "0.3", expect: 0.3, accuracy: money.Below},
+		{input: "-0.3", expect: -0.3, accuracy: money.Above},
+	}
+
+	for i, test := range table {
+		f, acc := money.MustParseDecimal(test.input).Float64WithAccuracy()
+		if test.expect != f {
+			t.Errorf("#%d - expect %f, but got %f", i, test.expect, f)
+		}
+		if test.accuracy != acc {
+			t.Errorf("#%d - expect %s, but got %s", i, test.accuracy, acc)
+		}
+	}
+}
+
 func TestMinDecimal(t *testing.T) {
 	t.Parallel()
 
@@ -189,124 +521,376 @@ func TestDecimal_String(t *testing.T) {
 	}
 }
 
-func TestDecimal_Abs(t *testing.T) {
+func TestDecimal_StringTrunc(t *testing.T) {
 	t.Parallel()
 
+	third := money.MustParseDecimal("1").Div(money.MustParseDecimal("3"))
+	twoThirds := money.MustParseDecimal("-2").Div(money.MustParseDecimal("3"))
+
 	table := []struct {
-		input  string
-		expect float64
+		input   money.Decimal
+		maxFrac int32
+		expect  string
 	}{
-		{input: "1.0", expect: 1.0},
-		{input: "0.5", expect: 0.5},
-		{input: "0.", expect: 0.0},
-		{input: "-0.0", expect: 0.0},
-		{input: "-0.5", expect: 0.5},
-		{input: "-1.0", expect: 1.0},
+		{input: third, maxFrac: 2, expect: "0.33"},
+		{input: third, maxFrac: 6, expect: "0.333333"},
+		{input: twoThirds, maxFrac: 2, expect: "-0.66"},
+		{input: twoThirds, maxFrac: 6, expect: "-0.666666"},
+		{input: money.MustParseDecimal("120.5"), maxFrac: 2, expect: "120.5"},
+		{input: money.MustParseDecimal("120.599"), maxFrac: 0, expect: "120.0"},
 	}
 
 	for i, test := range table {
-		dec, err := money.ParseDecimal(test.input)
-		if err != nil {
-			t.Fatal(err)
-		}
-		res := dec.Abs().Float64()
-
+		res := test.input.StringTrunc(test.maxFrac)
 		if test.expect != res {
-			t.Errorf("#%d - expect %f, but got %f", i, test.expect, res)
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, res)
 		}
 	}
 }
 
-func TestDecimal_Add(t *testing.T) {
+func TestDecimal_StringSep(t *testing.T) {
 	t.Parallel()
 
 	table := []struct {
-		input  decPair
-		expect float64
+		input  string
+		expect string
 	}{
-		{input: decPair{X: "1.0", Y: "1.0"}, expect: 2.0},
-		{input: decPair{X: "1.0", Y: "-1.0"}, expect: 0.0},
-		{input: decPair{X: "-1.0", Y: "1.0"}, expect: 0.0},
-		{input: decPair{X: "-1.0", Y: "-1.0"}, expect: -2.0},
-		{input: decPair{X: "1.0", Y: "0.0001"}, expect: 1.0001},
-		{input: decPair{X: "2454495034.0", Y: "3451204593.0"}, expect: 5905699627},
-		{input: decPair{X: "24544.95034", Y: "0.3451204593"}, expect: 24545.2954604593},
+		{input: "120,0", expect: "120,0"},
+		{input: "120,50", expect: "120,50"},
+		{input: "-120,123", expect: "-120,123"},
+		{input: "0,00000001", expect: "0,00000001"},
+		{input: "120", expect: "120,0"},
 	}
 
 	for i, test := range table {
-		x, err := money.ParseDecimal(test.input.X)
+		dec, err := money.ParseDecimalSep(test.input, ',')
 		if err != nil {
 			t.Fatal(err)
 		}
-		y, err := money.ParseDecimal(test.input.Y)
+
+		res := dec.StringSep(',')
+		if test.expect != res {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, res)
+		}
+
+		back, err := money.ParseDecimalSep(res, ',')
 		if err != nil {
 			t.Fatal(err)
 		}
+		if !dec.Equal(back) {
+			t.Errorf("#%d - expect round-trip %s, but got %s", i, dec, back)
+		}
+	}
+}
 
-		res := x.Add(y).Float64()
-		if test.expect != res {
-			t.Errorf("#%d - expect %f, but got %f", i, test.expect, res)
+func TestAlignScale(t *testing.T) {
+	t.Parallel()
+
+	res := money.AlignScale(
+		money.MustParseDecimal("1.5"),
+		money.MustParseDecimal("-2"),
+		money.MustParseDecimal("0.125"),
+	)
+	expect := []string{"1.500", "-2.000", "0.125"}
+	for i, d := range res {
+		if expect[i] != d.String() {
+			t.Errorf("#%d - expect %s, but got %s", i, expect[i], d)
 		}
 	}
+
+	if res := money.AlignScale(); len(res) != 0 {
+		t.Errorf("expect no decimals, but got %d", len(res))
+	}
 }
 
-func TestDecimal_Sub(t *testing.T) {
+func TestSumDecimal(t *testing.T) {
 	t.Parallel()
 
 	table := []struct {
-		input  decPair
-		expect float64
+		input  []string
+		expect string
 	}{
-		{input: decPair{X: "1.0", Y: "1.0"}, expect: 0.0},
-		{input: decPair{X: "1.0", Y: "-1.0"}, expect: 2.0},
-		{input: decPair{X: "-1.0", Y: "1.0"}, expect: -2.0},
-		{input: decPair{X: "-1.0", Y: "-1.0"}, expect: 0.0},
-		{input: decPair{X: "1.0", Y: "0.0001"}, expect: 0.9999},
-		{input: decPair{X: "2454495034.0", Y: "3451204593.0"}, expect: -996709559},
-		{input: decPair{X: "24544.95034", Y: "0.3451204593"}, expect: 24544.6052195407},
+		{input: []string{}, expect: "0.0"},
+		{input: []string{"1.5"}, expect: "1.5"},
+		{input: []string{"1.5", "2", "0.125"}, expect: "3.625"},
+		{input: []string{"1.50", "-1.5"}, expect: "0.00"},
+		{input: []string{"1000", "0.01", "-0.001"}, expect: "1000.009"},
 	}
 
 	for i, test := range table {
-		x, err := money.ParseDecimal(test.input.X)
-		if err != nil {
-			t.Fatal(err)
-		}
-		y, err := money.ParseDecimal(test.input.Y)
-		if err != nil {
-			t.Fatal(err)
+		ds := make([]money.Decimal, len(test.input))
+		for k, s := range test.input {
+			ds[k] = money.MustParseDecimal(s)
 		}
-
-		res := x.Sub(y).Float64()
-		if test.expect != res {
-			t.Errorf("#%d - expect %f, but got %f", i, test.expect, res)
+		if res := money.SumDecimal(ds...).String(); test.expect != res {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, res)
 		}
 	}
 }
 
-func TestDecimal_Mul(t *testing.T) {
+func TestSumCompensated(t *testing.T) {
 	t.Parallel()
 
+	ten := make([]float64, 10)
+	strs := make([]string, 10)
+	for i := range ten {
+		ten[i], strs[i] = 0.1, "0.1"
+	}
+
 	table := []struct {
-		input  decPair
-		expect float64
+		input       []float64
+		strings     []string
+		imprecision string
 	}{
-		{input: decPair{X: "1.0", Y: "1.0"}, expect: 1.0},
-		{input: decPair{X: "1.0", Y: "-1.0"}, expect: -1.0},
-		{input: decPair{X: "-1.0", Y: "1.0"}, expect: -1.0},
-		{input: decPair{X: "-1.0", Y: "-1.0"}, expect: 1.0},
-		{input: decPair{X: "1.0", Y: "0.0001"}, expect: 0.0001},
-		{input: decPair{X: "2454495034.0", Y: "3451204593.0"}, expect: 8470964534836491162},
-		{input: decPair{X: "24544.95034", Y: "0.3451204593"}, expect: 8470.964534836491162},
+		{input: []float64{}, strings: []string{}, imprecision: "0"},
+		{input: []float64{0.5, 0.25, -2}, strings: []string{"0.5", "0.25", "-2"}, imprecision: "0"},
+		{
+			input:       ten,
+			strings:     strs,
+			imprecision: "0.000000000000000055511151231257827021181583404541015625",
+		},
+		{
+			input:       []float64{0.1, 0.2},
+			strings:     []string{"0.1", "0.2"},
+			imprecision: "0.000000000000000016653345369377348106354475021362304687500",
+		},
+		{
+			input:       []float64{0.1, -0.1},
+			strings:     []string{"0.1", "-0.1"},
+			imprecision: "0",
+		},
 	}
 
 	for i, test := range table {
-		x, err := money.ParseDecimal(test.input.X)
-		if err != nil {
-			t.Fatal(err)
+		ds := make([]money.Decimal, len(test.strings))
+		for k, s := range test.strings {
+			ds[k] = money.MustParseDecimal(s)
 		}
-		y, err := money.ParseDecimal(test.input.Y)
+
+		sum, imprecision, err := money.SumCompensated(test.input...)
 		if err != nil {
-			t.Fatal(err)
+			t.Fatalf("#%d - expect no error, but got %s", i, err)
+		}
+		if expect := money.SumDecimal(ds...); !expect.Equal(sum) {
+			t.Errorf("#%d - expect %s, but got %s", i, expect, sum)
+		}
+		if expect := money.MustParseDecimal(test.imprecision); !expect.Equal(imprecision) {
+			t.Errorf("#%d - expect imprecision %s, but got %s", i, expect, imprecision)
+		}
+	}
+
+	// Adding the floats first accumulates their error
+	var f float64
+	for _, v := range ten {
+		f += v
+	}
+	naive, err := money.NewDecimal(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := money.MustParseDecimal("0.9999999999999999"); !expect.Equal(naive) {
+		t.Errorf("expect %s, but got %s", expect, naive)
+	}
+
+	if _, _, err := money.SumCompensated(1, math.NaN()); err != money.ErrInvalidDecimal {
+		t.Errorf("expect error %s, but got %v", money.ErrInvalidDecimal, err)
+	}
+}
+
+func TestSumDecimal_Pairwise(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewSource(42))
+	for n := 0; n < 100; n++ {
+		ds := randomDecimals(r, 1+r.Intn(50))
+
+		expect := ds[0]
+		for _, d := range ds[1:] {
+			expect = expect.Add(d)
+		}
+		res := money.SumDecimal(ds...)
+		if !expect.Equal(res) || expect.Exponent() != res.Exponent() {
+			t.Fatalf("#%d - expect %s, but got %s", n, expect, res)
+		}
+	}
+}
+
+// randomDecimals returns n decimals with up to 18 digits and 0 to 9 fraction
+// digits
+func randomDecimals(r *rand.Rand, n int) []money.Decimal {
+	ds := make([]money.Decimal, n)
+	for i := range ds {
+		v := strconv.FormatInt(r.Int63n(1e18)-5e17, 10)
+		if frac := r.Intn(10); frac > 0 {
+			v = fmt.Sprintf("%s.%0*d", v, frac, r.Int63n(int64(math.Pow10(frac))))
+		}
+		ds[i] = money.MustParseDecimal(v)
+	}
+	return ds
+}
+
+func BenchmarkSumDecimal(b *testing.B) {
+	ds := randomDecimals(rand.New(rand.NewSource(42)), 1000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		money.SumDecimal(ds...)
+	}
+}
+
+func BenchmarkSumDecimal_Pairwise(b *testing.B) {
+	ds := randomDecimals(rand.New(rand.NewSource(42)), 1000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sum := ds[0]
+		for _, d := range ds[1:] {
+			sum = sum.Add(d)
+		}
+	}
+}
+
+func TestSortDecimals(t *testing.T) {
+	t.Parallel()
+
+	input := []money.Decimal{
+		money.MustParseDecimal("1.10"),
+		money.MustParseDecimal("-2"),
+		money.MustParseDecimal("0.0001"),
+		money.MustParseDecimal("1.1"),
+		money.MustParseDecimal("1.099"),
+		money.MustParseDecimal("0.00"),
+	}
+	expect := []string{"-2.0", "0.00", "0.0001", "1.099", "1.1", "1.1"}
+
+	money.SortDecimals(input)
+	for i, d := range input {
+		if !money.MustParseDecimal(expect[i]).Equal(d) {
+			t.Errorf("#%d - expect %s, but got %s", i, expect[i], d)
+		}
+	}
+}
+
+func TestDecimal_Abs(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  string
+		expect float64
+	}{
+		{input: "1.0", expect: 1.0},
+		{input: "0.5", expect: 0.5},
+		{input: "0.", expect: 0.0},
+		{input: "-0.0", expect: 0.0},
+		{input: "-0.5", expect: 0.5},
+		{input: "-1.0", expect: 1.0},
+	}
+
+	for i, test := range table {
+		dec, err := money.ParseDecimal(test.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := dec.Abs().Float64()
+
+		if test.expect != res {
+			t.Errorf("#%d - expect %f, but got %f", i, test.expect, res)
+		}
+	}
+}
+
+func TestDecimal_Add(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  decPair
+		expect float64
+	}{
+		{input: decPair{X: "1.0", Y: "1.0"}, expect: 2.0},
+		{input: decPair{X: "1.0", Y: "-1.0"}, expect: 0.0},
+		{input: decPair{X: "-1.0", Y: "1.0"}, expect: 0.0},
+		{input: decPair{X: "-1.0", Y: "-1.0"}, expect: -2.0},
+		{input: decPair{X: "1.0", Y: "0.0001"}, expect: 1.0001},
+		{input: decPair{X: "2454495034.0", Y: "3451204593.0"}, expect: 5905699627},
+		{input: decPair{X: "24544.95034", Y: "0.3451204593"}, expect: 24545.2954604593},
+	}
+
+	for i, test := range table {
+		x, err := money.ParseDecimal(test.input.X)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y, err := money.ParseDecimal(test.input.Y)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res := x.Add(y).Float64()
+		if test.expect != res {
+			t.Errorf("#%d - expect %f, but got %f", i, test.expect, res)
+		}
+	}
+}
+
+func TestDecimal_Sub(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  decPair
+		expect float64
+	}{
+		{input: decPair{X: "1.0", Y: "1.0"}, expect: 0.0},
+		{input: decPair{X: "1.0", Y: "-1.0"}, expect: 2.0},
+		{input: decPair{X: "-1.0", Y: "1.0"}, expect: -2.0},
+		{input: decPair{X: "-1.0", Y: "-1.0"}, expect: 0.0},
+		{input: decPair{X: "1.0", Y: "0.0001"}, expect: 0.9999},
+		{input: decPair{X: "2454495034.0", Y: "3451204593.0"}, expect: -996709559},
+		{input: decPair{X: "24544.95034", Y: "0.3451204593"}, expect: 24544.6052195407},
+	}
+
+	for i, test := range table {
+		x, err := money.ParseDecimal(test.input.X)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y, err := money.ParseDecimal(test.input.Y)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res := x.Sub(y).Float64()
+		if test.expect != res {
+			t.Errorf("#%d - expect %f, but got %f", i, test.expect, res)
+		}
+	}
+}
+
+func TestDecimal_Mul(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  decPair
+		expect float64
+	}{
+		{input: decPair{X: "1.0", Y: "1.0"}, expect: 1.0},
+		{input: decPair{X: "1.0", Y: "-1.0"}, expect: -1.0},
+		{input: decPair{X: "-1.0", Y: "1.0"}, expect: -1.0},
+		{input: decPair{X: "-1.0", Y: "-1.0"}, expect: 1.0},
+		{input: decPair{X: "1.0", Y: "0.0001"}, expect: 0.0001},
+		{input: decPair{X: "2454495034.0", Y: "3451204593.0"}, expect: 8470964534836491162},
+		{input: decPair{X: "24544.95034", Y: "0.3451204593"}, expect: 8470.964534836491162},
+	}
+
+	for i, test := range table {
+		x, err := money.ParseDecimal(test.input.X)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y, err := money.ParseDecimal(test.input.Y)
+		if err != nil {
+			t.Fatal(err)
 		}
 
 		res := x.Mul(y).Float64()
@@ -351,6 +935,28 @@ func TestDecimal_Div(t *testing.T) {
 	}
 }
 
+func TestDecimal_Div_Negative(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  decPair
+		expect string
+	}{
+		{input: decPair{X: "2", Y: "3"}, expect: "0.6666666666666667"},
+		{input: decPair{X: "-2", Y: "3"}, expect: "-0.6666666666666667"},
+		{input: decPair{X: "2", Y: "-3"}, expect: "-0.6666666666666667"},
+		{input: decPair{X: "-2", Y: "-3"}, expect: "0.6666666666666667"},
+		{input: decPair{X: "-1", Y: "3"}, expect: "-0.3333333333333333"},
+	}
+
+	for i, test := range table {
+		res := money.MustParseDecimal(test.input.X).Div(money.MustParseDecimal(test.input.Y))
+		if test.expect != res.String() {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, res)
+		}
+	}
+}
+
 func TestDecimal_Neg(t *testing.T) {
 	t.Parallel()
 
@@ -411,89 +1017,78 @@ func TestDecimal_Mod(t *testing.T) {
 	}
 }
 
-func TestDecimal_Round(t *testing.T) {
+func TestDecimal_IsDivisibleBy(t *testing.T) {
 	t.Parallel()
 
 	table := []struct {
-		input  string
-		expect float64
-		prec   int32
+		input  decPair
+		expect bool
 	}{
-		{input: "120.0", prec: 1, expect: 120},
-		{input: "120.00", prec: 2, expect: 120},
-		{input: "120.000", prec: 3, expect: 120},
-		{input: "0.00000001", prec: 8, expect: 0.00000001},
-		{input: "17950000000000.0", prec: 1, expect: 17950000000000},
-		{input: "3.141592653589793", prec: 2, expect: 3.14},
-		{input: "0.11115", prec: 2, expect: 0.11},
-		{input: "0.11115", prec: 3, expect: 0.111},
-		{input: "0.11115", prec: 4, expect: 0.1112},
-		{input: "0.101", prec: 2, expect: 0.10},
-		{input: "0.102", prec: 2, expect: 0.10},
-		{input: "0.103", prec: 2, expect: 0.10},
-		{input: "0.104", prec: 2, expect: 0.10},
-		{input: "0.105", prec: 2, expect: 0.11},
-		{input: "0.106", prec: 2, expect: 0.11},
-		{input: "0.107", prec: 2, expect: 0.11},
-		{input: "0.108", prec: 2, expect: 0.11},
-		{input: "0.109", prec: 2, expect: 0.11},
+		{input: decPair{X: "1.15", Y: "0.05"}, expect: true},
+		{input: decPair{X: "1.17", Y: "0.05"}, expect: false},
+		{input: decPair{X: "-1.15", Y: "0.05"}, expect: true},
+		{input: decPair{X: "-1.17", Y: "0.05"}, expect: false},
+		{input: decPair{X: "1.15", Y: "-0.05"}, expect: true},
+		{input: decPair{X: "1.1500", Y: "0.05"}, expect: true},
+		{input: decPair{X: "0.0", Y: "0.05"}, expect: true},
+		{input: decPair{X: "10", Y: "0.25"}, expect: true},
+		{input: decPair{X: "0.00000001", Y: "0.01"}, expect: false},
 	}
 
 	for i, test := range table {
-		dec, err := money.ParseDecimal(test.input)
+		x, err := money.ParseDecimal(test.input.X)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y, err := money.ParseDecimal(test.input.Y)
 		if err != nil {
 			t.Fatal(err)
 		}
-		res := dec.Round(test.prec).Float64()
 
+		res := x.IsDivisibleBy(y)
 		if test.expect != res {
-			t.Errorf("#%d - expect %f, but got %f", i, test.expect, res)
+			t.Errorf("#%d - expect %t, but got %t - %s/%s", i, test.expect, res, test.input.X, test.input.Y)
 		}
 	}
 }
 
-func TestDecimal_RoundUp(t *testing.T) {
+func TestDecimal_RoundE(t *testing.T) {
 	t.Parallel()
 
 	table := []struct {
 		input  string
-		expect float64
-		prec   int32
+		places int32
+		expect string
+		err    error
 	}{
-		{input: "120.0", prec: 1, expect: 120},
-		{input: "120.00", prec: 2, expect: 120},
-		{input: "120.000", prec: 3, expect: 120},
-		{input: "0.00000001", prec: 8, expect: 0.00000001},
-		{input: "17950000000000.0", prec: 1, expect: 17950000000000},
-		{input: "3.141592653589793", prec: 2, expect: 3.15},
-		{input: "0.11115", prec: 2, expect: 0.12},
-		{input: "0.11115", prec: 3, expect: 0.112},
-		{input: "0.11115", prec: 4, expect: 0.1112},
-		{input: "0.101", prec: 2, expect: 0.11},
-		{input: "0.102", prec: 2, expect: 0.11},
-		{input: "0.103", prec: 2, expect: 0.11},
-		{input: "0.104", prec: 2, expect: 0.11},
-		{input: "0.105", prec: 2, expect: 0.11},
-		{input: "0.106", prec: 2, expect: 0.11},
-		{input: "0.107", prec: 2, expect: 0.11},
-		{input: "0.108", prec: 2, expect: 0.11},
-		{input: "0.109", prec: 2, expect: 0.11},
+		{input: "1.235", places: 2, expect: "1.24"},
+		{input: "-1.235", places: 2, expect: "-1.24"},
+		{input: "1235", places: -2, expect: "1200"},
+		{input: "1.5", places: 100, expect: "1.5"},
+		// Pathological precisions
+		{input: "1.5", places: math.MaxInt32, err: money.ErrInvalidPrecision},
+		{input: "1.5", places: math.MinInt32, err: money.ErrInvalidPrecision},
+		{input: "1.5", places: math.MaxInt32 - 1, err: money.ErrInvalidPrecision},
+		{input: "1.5", places: -1000000000, err: money.ErrInvalidPrecision},
+		{input: "1.5", places: 1000000, err: money.ErrInvalidPrecision},
 	}
 
 	for i, test := range table {
-		dec, err := money.ParseDecimal(test.input)
+		res, err := money.MustParseDecimal(test.input).RoundE(test.places)
+		if test.err != err {
+			t.Errorf("#%d - expect error %v, but got %v", i, test.err, err)
+			continue
+		}
 		if err != nil {
-			t.Fatal(err)
+			continue
 		}
-		res := dec.RoundUp(test.prec).Float64()
-
-		if test.expect != res {
-			t.Errorf("#%d - expect %f, but got %f", i, test.expect, res)
+		if expect := money.MustParseDecimal(test.expect); !expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, expect, res)
 		}
 	}
 }
 
-func TestDecimal_RoundDown(t *testing.T) {
+func TestDecimal_Round(t *testing.T) {
 	t.Parallel()
 
 	table := []struct {
@@ -509,17 +1104,16 @@ func TestDecimal_RoundDown(t *testing.T) {
 		{input: "3.141592653589793", prec: 2, expect: 3.14},
 		{input: "0.11115", prec: 2, expect: 0.11},
 		{input: "0.11115", prec: 3, expect: 0.111},
-		{input: "0.11115", prec: 4, expect: 0.1111},
+		{input: "0.11115", prec: 4, expect: 0.1112},
 		{input: "0.101", prec: 2, expect: 0.10},
 		{input: "0.102", prec: 2, expect: 0.10},
 		{input: "0.103", prec: 2, expect: 0.10},
 		{input: "0.104", prec: 2, expect: 0.10},
-		{input: "0.105", prec: 2, expect: 0.10},
-		{input: "0.106", prec: 2, expect: 0.10},
-		{input: "0.107", prec: 2, expect: 0.10},
-		{input: "0.108", prec: 2, expect: 0.10},
-		{input: "0.109", prec: 2, expect: 0.10},
-		{input: "1.88", prec: 0, expect: 1.00},
+		{input: "0.105", prec: 2, expect: 0.11},
+		{input: "0.106", prec: 2, expect: 0.11},
+		{input: "0.107", prec: 2, expect: 0.11},
+		{input: "0.108", prec: 2, expect: 0.11},
+		{input: "0.109", prec: 2, expect: 0.11},
 	}
 
 	for i, test := range table {
@@ -527,7 +1121,7 @@ func TestDecimal_RoundDown(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		res := dec.RoundDown(test.prec).Float64()
+		res := dec.Round(test.prec).Float64()
 
 		if test.expect != res {
 			t.Errorf("#%d - expect %f, but got %f", i, test.expect, res)
@@ -535,15 +1129,271 @@ func TestDecimal_RoundDown(t *testing.T) {
 	}
 }
 
-func TestDecimal_RoundNearest(t *testing.T) {
+func TestDecimal_FitsScale(t *testing.T) {
 	t.Parallel()
 
 	table := []struct {
 		input  string
-		expect float64
-		unit   float64
+		scale  int32
+		expect bool
 	}{
-		{input: "120.0", unit: 0.05, expect: 120},
+		{input: "1.2300", scale: 2, expect: true},
+		{input: "1.234", scale: 2, expect: false},
+		{input: "-1.234", scale: 2, expect: false},
+		{input: "-1.2300", scale: 2, expect: true},
+		{input: "1.23", scale: 4, expect: true},
+		{input: "120", scale: 0, expect: true},
+		{input: "120.000", scale: 0, expect: true},
+		{input: "120.001", scale: 0, expect: false},
+		{input: "0.00000000", scale: 0, expect: true},
+		{input: "1200", scale: -2, expect: true},
+		{input: "1250", scale: -2, expect: false},
+	}
+
+	for i, test := range table {
+		if res := money.MustParseDecimal(test.input).FitsScale(test.scale); test.expect != res {
+			t.Errorf("#%d - expect %t, but got %t - %s", i, test.expect, res, test.input)
+		}
+	}
+}
+
+func TestDecimal_RoundSignificant(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  string
+		figs   int32
+		expect string
+	}{
+		{input: "12345", figs: 3, expect: "12300"},
+		{input: "12355", figs: 3, expect: "12400"},
+		{input: "-12355", figs: 3, expect: "-12400"},
+		{input: "0.00012345", figs: 3, expect: "0.000123"},
+		{input: "0.00012355", figs: 3, expect: "0.000124"},
+		{input: "123.456", figs: 4, expect: "123.5"},
+		{input: "99950", figs: 3, expect: "100000"},
+		{input: "1.2", figs: 3, expect: "1.20"},
+		{input: "0", figs: 3, expect: "0"},
+		{input: "0.000", figs: 1, expect: "0.000"},
+	}
+
+	for i, test := range table {
+		res := money.MustParseDecimal(test.input).RoundSignificant(test.figs)
+		expect := money.MustParseDecimal(test.expect)
+		if !expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, expect, res)
+		}
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expect RoundSignificant to panic with 0 figures")
+			}
+		}()
+		money.MustParseDecimal("1.5").RoundSignificant(0)
+	}()
+}
+
+func TestDecimal_SIString(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  string
+		figs   int32
+		expect string
+	}{
+		{input: "1500000", figs: 3, expect: "1.5M"},
+		{input: "0.0015", figs: 3, expect: "1.5m"},
+		{input: "-1500000", figs: 3, expect: "-1.5M"},
+		{input: "-0.0015", figs: 3, expect: "-1.5m"},
+		{input: "123456", figs: 2, expect: "120k"},
+		{input: "123456", figs: 4, expect: "123.5k"},
+		{input: "999.95", figs: 4, expect: "1k"},
+		{input: "999999", figs: 3, expect: "1M"},
+		{input: "12.5", figs: 3, expect: "12.5"},
+		{input: "1", figs: 3, expect: "1"},
+		{input: "0.000042", figs: 3, expect: "42µ"},
+		{input: "0.000000007", figs: 3, expect: "7n"},
+		{input: "3400000000", figs: 3, expect: "3.4G"},
+		{input: "2000000000000", figs: 3, expect: "2T"},
+		// Beyond the prefixes
+		{input: "5000000000000000", figs: 3, expect: "5000T"},
+		{input: "0.0000000000012", figs: 3, expect: "0.0012n"},
+		{input: "0.00", figs: 3, expect: "0"},
+	}
+
+	for i, test := range table {
+		if res := money.MustParseDecimal(test.input).SIString(test.figs); test.expect != res {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, res)
+		}
+	}
+}
+
+func TestDecimal_RoundSignificantFraction(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  string
+		figs   int32
+		expect string
+	}{
+		{input: "0.00004567", figs: 3, expect: "0.0000457"},
+		{input: "-0.00004567", figs: 3, expect: "-0.0000457"},
+		{input: "0.00004567", figs: 2, expect: "0.000046"},
+		{input: "0.00004567", figs: 8, expect: "0.00004567"},
+		{input: "12.00004567", figs: 3, expect: "12.0000457"},
+		{input: "12345.6789", figs: 2, expect: "12345.68"},
+		{input: "1.9999", figs: 2, expect: "2.00"},
+		{input: "120.00", figs: 2, expect: "120.00"},
+		{input: "120", figs: 2, expect: "120"},
+	}
+
+	for i, test := range table {
+		res := money.MustParseDecimal(test.input).RoundSignificantFraction(test.figs)
+		if test.expect != res.String() && !(res.Exponent() >= 0 && money.MustParseDecimal(test.expect).Equal(res)) {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, res)
+		}
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expect RoundSignificantFraction to panic with 0 figures")
+			}
+		}()
+		money.MustParseDecimal("1.5").RoundSignificantFraction(0)
+	}()
+}
+
+func TestDecimal_RoundUp(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  string
+		expect float64
+		prec   int32
+	}{
+		{input: "120.0", prec: 1, expect: 120},
+		{input: "120.00", prec: 2, expect: 120},
+		{input: "120.000", prec: 3, expect: 120},
+		{input: "0.00000001", prec: 8, expect: 0.00000001},
+		{input: "17950000000000.0", prec: 1, expect: 17950000000000},
+		{input: "3.141592653589793", prec: 2, expect: 3.15},
+		{input: "0.11115", prec: 2, expect: 0.12},
+		{input: "0.11115", prec: 3, expect: 0.112},
+		{input: "0.11115", prec: 4, expect: 0.1112},
+		{input: "0.101", prec: 2, expect: 0.11},
+		{input: "0.102", prec: 2, expect: 0.11},
+		{input: "0.103", prec: 2, expect: 0.11},
+		{input: "0.104", prec: 2, expect: 0.11},
+		{input: "0.105", prec: 2, expect: 0.11},
+		{input: "0.106", prec: 2, expect: 0.11},
+		{input: "0.107", prec: 2, expect: 0.11},
+		{input: "0.108", prec: 2, expect: 0.11},
+		{input: "0.109", prec: 2, expect: 0.11},
+	}
+
+	for i, test := range table {
+		dec, err := money.ParseDecimal(test.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := dec.RoundUp(test.prec).Float64()
+
+		if test.expect != res {
+			t.Errorf("#%d - expect %f, but got %f", i, test.expect, res)
+		}
+	}
+}
+
+func TestDecimal_RoundHalfDown(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  string
+		prec   int32
+		expect string
+	}{
+		// Ties
+		{input: "0.125", prec: 2, expect: "0.12"},
+		{input: "-0.125", prec: 2, expect: "-0.12"},
+		{input: "0.5", prec: 0, expect: "0"},
+		{input: "-0.5", prec: 0, expect: "0"},
+		{input: "2.5", prec: 0, expect: "2"},
+		{input: "-2.5", prec: 0, expect: "-2"},
+		{input: "125", prec: -1, expect: "120"},
+		// Around ties
+		{input: "0.1251", prec: 2, expect: "0.13"},
+		{input: "-0.1251", prec: 2, expect: "-0.13"},
+		{input: "0.1249", prec: 2, expect: "0.12"},
+		{input: "-0.1249", prec: 2, expect: "-0.12"},
+		{input: "0.12", prec: 2, expect: "0.12"},
+		{input: "0.12", prec: 4, expect: "0.1200"},
+	}
+
+	for i, test := range table {
+		res := money.MustParseDecimal(test.input).RoundHalfDown(test.prec)
+		if expect := money.MustParseDecimal(test.expect); !expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, expect, res)
+		}
+		if test.prec >= 0 && -res.Exponent() != test.prec {
+			t.Errorf("#%d - expect precision %d, but got %d", i, test.prec, -res.Exponent())
+		}
+	}
+}
+
+func TestDecimal_RoundDown(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  string
+		expect float64
+		prec   int32
+	}{
+		{input: "120.0", prec: 1, expect: 120},
+		{input: "120.00", prec: 2, expect: 120},
+		{input: "120.000", prec: 3, expect: 120},
+		{input: "0.00000001", prec: 8, expect: 0.00000001},
+		{input: "17950000000000.0", prec: 1, expect: 17950000000000},
+		{input: "3.141592653589793", prec: 2, expect: 3.14},
+		{input: "0.11115", prec: 2, expect: 0.11},
+		{input: "0.11115", prec: 3, expect: 0.111},
+		{input: "0.11115", prec: 4, expect: 0.1111},
+		{input: "0.101", prec: 2, expect: 0.10},
+		{input: "0.102", prec: 2, expect: 0.10},
+		{input: "0.103", prec: 2, expect: 0.10},
+		{input: "0.104", prec: 2, expect: 0.10},
+		{input: "0.105", prec: 2, expect: 0.10},
+		{input: "0.106", prec: 2, expect: 0.10},
+		{input: "0.107", prec: 2, expect: 0.10},
+		{input: "0.108", prec: 2, expect: 0.10},
+		{input: "0.109", prec: 2, expect: 0.10},
+		{input: "1.88", prec: 0, expect: 1.00},
+	}
+
+	for i, test := range table {
+		dec, err := money.ParseDecimal(test.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := dec.RoundDown(test.prec).Float64()
+
+		if test.expect != res {
+			t.Errorf("#%d - expect %f, but got %f", i, test.expect, res)
+		}
+	}
+}
+
+func TestDecimal_RoundNearest(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  string
+		expect float64
+		unit   float64
+	}{
+		{input: "120.0", unit: 0.05, expect: 120},
 		{input: "120.00", unit: 0.05, expect: 120},
 		{input: "120.000", unit: 0.05, expect: 120},
 		{input: "0.00000001", unit: 0.05, expect: 0.00},
@@ -562,6 +1412,10 @@ func TestDecimal_RoundNearest(t *testing.T) {
 		{input: "0.17", unit: 0.05, expect: 0.15},
 		{input: "0.18", unit: 0.05, expect: 0.20},
 		{input: "0.19", unit: 0.05, expect: 0.20},
+		{input: "12.75", unit: 0.5, expect: 13},
+		{input: "12.25", unit: 0.5, expect: 12.5},
+		{input: "-12.75", unit: 0.5, expect: -13},
+		{input: "0.125", unit: 0.25, expect: 0.25},
 		{input: "-0.10", unit: 0.05, expect: -0.10},
 		{input: "-0.11", unit: 0.05, expect: -0.10},
 		{input: "-0.12", unit: 0.05, expect: -0.10},
@@ -604,6 +1458,184 @@ func TestDecimal_RoundNearest(t *testing.T) {
 	}
 }
 
+func TestParseDecimalPreserveScale(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  string
+		expect string
+		exp    int32
+	}{
+		{input: "120.50", expect: "120.50", exp: -2},
+		{input: "120.5", expect: "120.5", exp: -1},
+		{input: "0.000", expect: "0.000", exp: -3},
+		{input: "-7.10", expect: "-7.10", exp: -2},
+	}
+
+	for i, test := range table {
+		res, err := money.ParseDecimalPreserveScale(test.input)
+		if err != nil {
+			t.Fatalf("#%d - expect no error, but got %s", i, err)
+		}
+		if test.expect != res.String() || test.exp != res.Exponent() {
+			t.Errorf("#%d - expect %s (%d), but got %s (%d)", i, test.expect, test.exp, res, res.Exponent())
+		}
+	}
+
+	if _, err := money.ParseDecimalPreserveScale("1.2.3"); err == nil {
+		t.Error("expect an error")
+	}
+}
+
+func TestDecimal_WithScale(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  float64
+		scale  int32
+		expect string
+	}{
+		{input: 120.50, scale: 2, expect: "120.50"},
+		{input: 120.5, scale: 4, expect: "120.5000"},
+		{input: 120, scale: 2, expect: "120.00"},
+		{input: 120.567, scale: 2, expect: "120.57"},
+		{input: -0.125, scale: 2, expect: "-0.13"},
+		{input: 0.1, scale: 1, expect: "0.1"},
+	}
+
+	for i, test := range table {
+		d, err := money.NewDecimal(test.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res := d.WithScale(test.scale).String(); test.expect != res {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, res)
+		}
+	}
+}
+
+func TestDecimal_SnapTo(t *testing.T) {
+	t.Parallel()
+
+	allowed := []money.Decimal{
+		money.MustParseDecimal("0.99"),
+		money.MustParseDecimal("1.49"),
+		money.MustParseDecimal("1.99"),
+	}
+
+	table := []struct {
+		input  string
+		expect string
+	}{
+		{input: "0", expect: "0.99"},
+		{input: "-5", expect: "0.99"},
+		{input: "0.99", expect: "0.99"},
+		{input: "1.2", expect: "0.99"},
+		{input: "1.24", expect: "0.99"},
+		{input: "1.2400", expect: "0.99"},
+		{input: "1.2401", expect: "1.49"},
+		{input: "1.49", expect: "1.49"},
+		{input: "1.74", expect: "1.49"},
+		{input: "1.75", expect: "1.99"},
+		{input: "1.99", expect: "1.99"},
+		{input: "5.00", expect: "1.99"},
+	}
+
+	for i, test := range table {
+		res, err := money.MustParseDecimal(test.input).SnapTo(allowed)
+		if err != nil {
+			t.Fatalf("#%d - expect no error, but got %s", i, err)
+		}
+		if expect := money.MustParseDecimal(test.expect); !expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, expect, res)
+		}
+	}
+
+	if _, err := money.MustParseDecimal("1").SnapTo(nil); err != money.ErrNoAllowedValues {
+		t.Errorf("expect error %s, but got %v", money.ErrNoAllowedValues, err)
+	}
+}
+
+func TestDecimal_RoundNearest_Satoshi(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  string
+		unit   string
+		expect string
+	}{
+		{input: "0.00000001", unit: "0.00000001", expect: "0.00000001"},
+		{input: "0.000000014", unit: "0.00000001", expect: "0.00000001"},
+		{input: "0.0000000149999999", unit: "0.00000001", expect: "0.00000001"},
+		{input: "0.000000015", unit: "0.00000001", expect: "0.00000002"},
+		{input: "0.0000000150000001", unit: "0.00000001", expect: "0.00000002"},
+		{input: "0.000000005", unit: "0.00000001", expect: "0.00000001"},
+		{input: "0.000000004999", unit: "0.00000001", expect: "0"},
+		{input: "-0.000000014", unit: "0.00000001", expect: "-0.00000001"},
+		{input: "-0.000000015", unit: "0.00000001", expect: "-0.00000002"},
+		{input: "-0.0000000149999999", unit: "0.00000001", expect: "-0.00000001"},
+		{input: "21000000.123456785", unit: "0.00000001", expect: "21000000.12345679"},
+		{input: "21000000.1234567849", unit: "0.00000001", expect: "21000000.12345678"},
+		{input: "0.000000124", unit: "0.00000005", expect: "0.0000001"},
+		{input: "0.000000125", unit: "0.00000005", expect: "0.00000015"},
+		{input: "0.000000034", unit: "0.00000003", expect: "0.00000003"},
+		{input: "0.000000045", unit: "0.00000003", expect: "0.00000006"},
+		{input: "0.0000000449", unit: "0.00000003", expect: "0.00000003"},
+		{input: "1.0000000000000000004", unit: "0.000000000000000001", expect: "1"},
+		{input: "1.0000000000000000005", unit: "0.000000000000000001", expect: "1.000000000000000001"},
+		{input: "0.00000000000000000149", unit: "0.000000000000000001", expect: "0.000000000000000001"},
+		{input: "0.0000000000000000015", unit: "0.000000000000000001", expect: "0.000000000000000002"},
+		{input: "12345678901234567.89", unit: "0.00000001", expect: "12345678901234567.89"},
+		// Just below the midpoint, which rounding to the unit precision first
+		// would move onto it
+		{input: "0.000000245", unit: "0.00000050", expect: "0"},
+		{input: "12.745", unit: "0.50", expect: "12.50"},
+		{input: "1.045", unit: "0.10", expect: "1.00"},
+		{input: "-1.045", unit: "0.10", expect: "-1.00"},
+		{input: "0.00000025", unit: "0.00000050", expect: "0.0000005"},
+		{input: "-0.00000025", unit: "0.00000050", expect: "-0.0000005"},
+	}
+
+	for i, test := range table {
+		input := money.MustParseDecimal(test.input)
+		unit := money.MustParseDecimal(test.unit)
+		expect := money.MustParseDecimal(test.expect)
+
+		if res := input.RoundNearest(unit); !expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s - %s", i, expect, res, test.input)
+		}
+	}
+}
+
+func TestDecimal_TruncateReport(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input   string
+		prec    int32
+		expect  string
+		dropped bool
+	}{
+		{input: "123.456", prec: 2, expect: "123.45", dropped: true},
+		{input: "-123.456", prec: 0, expect: "-123.0", dropped: true},
+		{input: "0.00000001", prec: 7, expect: "0.0000000", dropped: true},
+		{input: "123.450", prec: 2, expect: "123.45", dropped: false},
+		{input: "123.45", prec: 2, expect: "123.45", dropped: false},
+		{input: "123.45", prec: 4, expect: "123.45", dropped: false},
+		{input: "120", prec: 0, expect: "120.0", dropped: false},
+	}
+
+	for i, test := range table {
+		res, dropped := money.MustParseDecimal(test.input).TruncateReport(test.prec)
+		if test.expect != res.String() {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, res)
+		}
+		if test.dropped != dropped {
+			t.Errorf("#%d - expect dropped %t, but got %t - %s", i, test.dropped, dropped, test.input)
+		}
+	}
+}
+
 func TestDecimal_Truncate(t *testing.T) {
 	t.Parallel()
 
@@ -757,33 +1789,94 @@ func TestDecimal_Cmp(t *testing.T) {
 	}
 }
 
-func TestDecimal_Equal(t *testing.T) {
+func TestSafeCmp(t *testing.T) {
 	t.Parallel()
 
 	table := []struct {
 		input  decPair
-		expect bool
+		expect int
 	}{
-		{input: decPair{X: "1.0", Y: "1.0"}, expect: true},
-		{input: decPair{X: "-1.0", Y: "1.0"}, expect: false},
-		{input: decPair{X: "-1.0", Y: "-1.0"}, expect: true},
-		{input: decPair{X: "0.0", Y: "0.0"}, expect: true},
-		{input: decPair{X: "1.0", Y: "-1.0"}, expect: false},
+		{input: decPair{X: "1.0", Y: "1.00"}, expect: 0},
+		{input: decPair{X: "1.0", Y: "-1.00"}, expect: 1},
+		{input: decPair{X: "-1.0", Y: "1.00"}, expect: -1},
+		{input: decPair{X: "0", Y: "0.000"}, expect: 0},
+		{input: decPair{X: "0", Y: "-0.001"}, expect: 1},
+		{input: decPair{X: "99.9", Y: "100"}, expect: -1},
+		{input: decPair{X: "-99.9", Y: "-100"}, expect: 1},
+		{input: decPair{X: "123.45", Y: "123.449"}, expect: 1},
 	}
 
 	for i, test := range table {
-		x, err := money.ParseDecimal(test.input.X)
+		x := money.MustParseDecimal(test.input.X)
+		y := money.MustParseDecimal(test.input.Y)
+		res, err := money.SafeCmp(x, y)
 		if err != nil {
-			t.Fatal(err)
+			t.Fatalf("#%d - %s", i, err)
 		}
-		y, err := money.ParseDecimal(test.input.Y)
-		if err != nil {
-			t.Fatal(err)
+		if test.expect != res || x.Cmp(y) != res {
+			t.Errorf("#%d - expect %d, but got %d", i, test.expect, res)
 		}
+	}
 
-		res := x.Equal(y)
-		if test.expect != res {
-			t.Errorf("#%d - expect %t, but got %t", i, test.expect, res)
+	// Exponents far apart must not be rescaled
+	huge := decimalFromBinaryV2(t, math.MaxInt32-10, 5)
+	tiny := decimalFromBinaryV2(t, math.MinInt32+10, 5)
+	if res, err := money.SafeCmp(huge, tiny); err != nil || res != 1 {
+		t.Errorf("expect 1, but got %d %v", res, err)
+	}
+	if res, err := money.SafeCmp(tiny, huge); err != nil || res != -1 {
+		t.Errorf("expect -1, but got %d %v", res, err)
+	}
+
+	// Corrupt state
+	corrupt := decimalFromBinaryV2(t, math.MaxInt32, 100)
+	if _, err := money.SafeCmp(corrupt, huge); err != money.ErrInvalidDecimal {
+		t.Errorf("expect error %s, but got %v", money.ErrInvalidDecimal, err)
+	}
+	if _, err := money.SafeCmp(money.DecimalOne(), corrupt); err != money.ErrInvalidDecimal {
+		t.Errorf("expect error %s, but got %v", money.ErrInvalidDecimal, err)
+	}
+}
+
+// decimalFromBinaryV2 builds the decimal value * 10^exp from its binary form
+func decimalFromBinaryV2(t *testing.T, exp int64, value byte) money.Decimal {
+	data := make([]byte, binary.MaxVarintLen64)
+	data = append(data[:binary.PutVarint(data, exp)], 0, value)
+
+	var d money.Decimal
+	if err := d.UnmarshalBinaryV2(data); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestDecimal_Equal(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  decPair
+		expect bool
+	}{
+		{input: decPair{X: "1.0", Y: "1.0"}, expect: true},
+		{input: decPair{X: "-1.0", Y: "1.0"}, expect: false},
+		{input: decPair{X: "-1.0", Y: "-1.0"}, expect: true},
+		{input: decPair{X: "0.0", Y: "0.0"}, expect: true},
+		{input: decPair{X: "1.0", Y: "-1.0"}, expect: false},
+	}
+
+	for i, test := range table {
+		x, err := money.ParseDecimal(test.input.X)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y, err := money.ParseDecimal(test.input.Y)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res := x.Equal(y)
+		if test.expect != res {
+			t.Errorf("#%d - expect %t, but got %t", i, test.expect, res)
 		}
 	}
 }
@@ -861,6 +1954,36 @@ func TestDecimal_Formatter(t *testing.T) {
 			lang:   language.English,
 			expect: "7.70",
 		},
+		{
+			input:  money.MustParseDecimal("17950000000000.005"),
+			lang:   language.English,
+			expect: "17,950,000,000,000.005",
+		},
+		{
+			input:  money.MustParseDecimal("-17950000000000.005"),
+			lang:   language.French,
+			expect: "-17 950 000 000 000,005",
+		},
+		{
+			input:  money.MustParseDecimal("123456789012345678901234.5"),
+			lang:   language.English,
+			expect: "123,456,789,012,345,678,901,234.5",
+		},
+		{
+			input:  money.MustParseDecimal("-23456789012345678901234.5"),
+			lang:   language.German,
+			expect: "-23.456.789.012.345.678.901.234,5",
+		},
+		{
+			input:  money.MustParseDecimal("123456789012345678901234.5"),
+			lang:   language.Make("hi"),
+			expect: "1,23,45,67,89,01,23,45,67,89,01,234.5",
+		},
+		{
+			input:  money.MustParseDecimal("9223372036854775808"),
+			lang:   language.French,
+			expect: "9 223 372 036 854 775 808",
+		},
 	}
 
 	for i, test := range table {
@@ -952,6 +2075,16 @@ func TestDecimal_PercentFormatter(t *testing.T) {
 			lang:   language.English,
 			expect: "7.7%",
 		},
+		{
+			input:  money.MustParseDecimal("17950000000000.005"),
+			lang:   language.English,
+			expect: "17,950,000,000,000.005%",
+		},
+		{
+			input:  money.MustParseDecimal("-17950000000000.005"),
+			lang:   language.German,
+			expect: "-17.950.000.000.000,005 %",
+		},
 	}
 
 	for i, test := range table {
@@ -991,6 +2124,36 @@ func TestDecimal_IsZero(t *testing.T) {
 	}
 }
 
+func TestDecimal_IsOne(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input   string
+		one     bool
+		hundred bool
+	}{
+		{input: "1", one: true},
+		{input: "1.0", one: true},
+		{input: "1.000", one: true},
+		{input: "0.999", one: false},
+		{input: "-1.0", one: false},
+		{input: "100", hundred: true},
+		{input: "100.00", hundred: true},
+		{input: "99.999", hundred: false},
+		{input: "0.0", one: false, hundred: false},
+	}
+
+	for i, test := range table {
+		x := money.MustParseDecimal(test.input)
+		if res := x.IsOne(); test.one != res {
+			t.Errorf("#%d - expect IsOne %t, but got %t", i, test.one, res)
+		}
+		if res := x.IsHundred(); test.hundred != res {
+			t.Errorf("#%d - expect IsHundred %t, but got %t", i, test.hundred, res)
+		}
+	}
+}
+
 func TestDecimal_Sign(t *testing.T) {
 	t.Parallel()
 
@@ -1054,6 +2217,85 @@ func TestDecimal_JSON(t *testing.T) {
 	}
 }
 
+func TestDecimal_UnmarshalJSON_Number(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  string
+		expect string
+		err    bool
+	}{
+		{input: `120.5`, expect: "120.5"},
+		{input: `120.50`, expect: "120.50"},
+		{input: `-0.001`, expect: "-0.001"},
+		{input: `0`, expect: "0.0"},
+		{input: `1.205e2`, expect: "120.5"},
+		{input: `1205E-3`, expect: "1.205"},
+		{input: `1.5e+1`, expect: "15"},
+		{input: `1e99999999999`, err: true},
+		{input: `1e100000000`, err: true},
+		{input: `1e-100000000`, err: true},
+		{input: `0e100000000`, err: true},
+		{input: `5e-7`, expect: "0.0000005"},
+	}
+
+	for i, test := range table {
+		d := money.Decimal{}
+		err := d.UnmarshalJSON([]byte(test.input))
+		if test.err {
+			if err == nil {
+				t.Errorf("#%d - expect an error, but got %s", i, d)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("#%d - %s", i, err)
+		}
+		if !money.MustParseDecimal(test.expect).Equal(d) {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, d)
+		}
+	}
+}
+
+func TestDecimal_NegativeZero(t *testing.T) {
+	t.Parallel()
+
+	d := money.MustParseDecimal
+	table := []struct {
+		input  money.Decimal
+		expect string
+	}{
+		{input: d("-1.50").Add(d("1.50")), expect: "0.00"},
+		{input: d("-1.50").Sub(d("-1.50")), expect: "0.00"},
+		{input: d("-0.00").Sub(d("0.00")), expect: "0.00"},
+		{input: d("-1.50").Mul(d("0.00")), expect: "0.0000"},
+		{input: d("-1.50").Mul(d("-0")), expect: "0.00"},
+		{input: d("-0.001").Round(2), expect: "0.00"},
+		{input: d("-0.4").Round(0), expect: "0.0"},
+		{input: d("-0.001").RoundNearest(d("0.05")), expect: "0.00"},
+		{input: d("-0.001").Truncate(2), expect: "0.00"},
+		{input: d("-0.00"), expect: "0.00"},
+		{input: d("-1.50").Add(d("1.50")).Neg(), expect: "0.00"},
+	}
+
+	for i, test := range table {
+		if res := test.input.String(); test.expect != res {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, res)
+		}
+		if test.input.Sign() != money.SignNeutral {
+			t.Errorf("#%d - expect sign %d, but got %d", i, money.SignNeutral, test.input.Sign())
+		}
+
+		data, err := json.Marshal(test.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expect := `"` + test.expect + `"`; expect != string(data) {
+			t.Errorf("#%d - expect JSON %s, but got %s", i, expect, data)
+		}
+	}
+}
+
 func TestDecimal_Gob(t *testing.T) {
 	t.Parallel()
 
@@ -1091,3 +2333,1058 @@ func TestDecimal_Gob(t *testing.T) {
 		}
 	}
 }
+
+func TestDecimal_BinaryV2(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input string
+	}{
+		{input: "1.0"},
+		{input: "-1.0"},
+		{input: "0.0"},
+		{input: "-0.00"},
+		{input: "0"},
+		{input: "0.00000001"},
+		{input: "-0.00000001"},
+		{input: "17950000000000.0"},
+		{input: "3.141592653589793"},
+		{input: "-123456789012345678901234567890.123456789"},
+	}
+
+	for i, test := range table {
+		x, err := money.ParseDecimal(test.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := x.MarshalBinaryV2()
+		if err != nil {
+			t.Fatal("cannot marshal binary", err)
+		}
+
+		y := money.Decimal{}
+		if err := y.UnmarshalBinaryV2(data); err != nil {
+			t.Fatal("cannot unmarshal binary", err)
+		}
+
+		if x.String() != y.String() {
+			t.Errorf("#%d - expect %s, but got %s", i, x, y)
+		}
+		if x.Exponent() != y.Exponent() {
+			t.Errorf("#%d - expect exponent %d, but got %d", i, x.Exponent(), y.Exponent())
+		}
+	}
+}
+
+func TestDecimal_UnmarshalBinaryV2_Invalid(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input []byte
+	}{
+		{input: nil},
+		{input: []byte{}},
+		{input: []byte{0x02}},
+		{input: []byte{0x02, 0x03, 0x01}},
+		{input: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 0x00}},
+	}
+
+	for i, test := range table {
+		var d money.Decimal
+		if err := d.UnmarshalBinaryV2(test.input); err != money.ErrInvalidDecimal {
+			t.Errorf("#%d - expect error %s, but got %s", i, money.ErrInvalidDecimal, err)
+		}
+	}
+}
+
+func BenchmarkDecimal_MarshalBinary(b *testing.B) {
+	x := money.MustParseDecimal("17950000000000.123456")
+
+	var size int
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		data, err := x.MarshalBinary()
+		if err != nil {
+			b.Fatal(err)
+		}
+		size = len(data)
+	}
+	b.ReportMetric(float64(size), "bytes")
+}
+
+func BenchmarkDecimal_MarshalBinaryV2(b *testing.B) {
+	x := money.MustParseDecimal("17950000000000.123456")
+
+	var size int
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		data, err := x.MarshalBinaryV2()
+		if err != nil {
+			b.Fatal(err)
+		}
+		size = len(data)
+	}
+	b.ReportMetric(float64(size), "bytes")
+}
+
+func TestDecimal_SharedValues(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		shared func() money.Decimal
+		expect string
+	}{
+		{shared: money.DecimalZero, expect: "0"},
+		{shared: money.DecimalOne, expect: "1"},
+		{shared: money.DecimalHundred, expect: "100"},
+	}
+
+	for i, test := range table {
+		fresh := money.MustParseDecimal(test.expect)
+		shared := test.shared()
+		if !fresh.Equal(shared) {
+			t.Errorf("#%d - expect %s, but got %s", i, fresh, shared)
+		}
+
+		// Arithmetic must behave identically and leave the shared value intact
+		x := money.MustParseDecimal("12.345")
+		if !x.Add(fresh).Equal(x.Add(shared)) {
+			t.Errorf("#%d - expect add to match, but got %s", i, x.Add(shared))
+		}
+		if !x.Mul(fresh).Equal(x.Mul(shared)) {
+			t.Errorf("#%d - expect mul to match, but got %s", i, x.Mul(shared))
+		}
+		shared.Neg().Abs().Sub(x)
+		if !fresh.Equal(test.shared()) {
+			t.Errorf("#%d - expect shared value to be unchanged, but got %s", i, test.shared())
+		}
+
+		// Mutating the returned value must not leak into the package values
+		shared.SetAdd(shared, x)
+		mutated := test.shared()
+		if err := mutated.SetString("3"); err != nil {
+			t.Fatalf("#%d - expect no error, but got %s", i, err)
+		}
+		if !fresh.Equal(test.shared()) {
+			t.Errorf("#%d - expect mutations to leave the value unchanged, but got %s", i, test.shared())
+		}
+	}
+
+	if !money.MustParseDecimal("100").IsHundred() {
+		t.Error("expect 100 to be hundred after mutating DecimalHundred")
+	}
+	if !money.MustParseDecimal("1").IsOne() {
+		t.Error("expect 1 to be one after mutating DecimalOne")
+	}
+	if !money.MustParseDecimal("0").IsZero() {
+		t.Error("expect 0 to be zero after mutating DecimalZero")
+	}
+}
+
+func BenchmarkDecimalOne(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		money.DecimalOne()
+	}
+}
+
+func BenchmarkNewDecimalOne(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := money.NewDecimal(1); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecimal_RoundNearest(b *testing.B) {
+	x := money.MustParseDecimal("3.1416")
+	unit := money.MustParseDecimal("0.05")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		x.RoundNearest(unit)
+	}
+}
+
+func TestDecimal_PowInt(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  string
+		n      int64
+		expect string
+	}{
+		{input: "2", n: 0, expect: "1"},
+		{input: "2", n: 1, expect: "2"},
+		{input: "2", n: 10, expect: "1024"},
+		{input: "1.05", n: 3, expect: "1.157625"},
+		{input: "-1.5", n: 3, expect: "-3.375"},
+		{input: "-1.5", n: 2, expect: "2.25"},
+		{input: "0.0", n: 2, expect: "0"},
+		{input: "2", n: -2, expect: "0.25"},
+		{input: "3", n: -1, expect: "0.3333333333333333"},
+		{input: "1", n: math.MinInt64, expect: "1"},
+		{input: "-1", n: math.MinInt64, expect: "1"},
+		{input: "-1", n: math.MaxInt64, expect: "-1"},
+	}
+
+	for i, test := range table {
+		x := money.MustParseDecimal(test.input)
+		expect := money.MustParseDecimal(test.expect)
+
+		res := x.PowInt(test.n)
+		if !expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, expect, res)
+		}
+	}
+
+	// The result is owned by the caller, so mutating it leaves one intact
+	res := money.MustParseDecimal("2").PowInt(0)
+	if err := res.SetString("7"); err != nil {
+		t.Fatalf("expect no error, but got %s", err)
+	}
+	if res := money.MustParseDecimal("3").PowInt(0); !res.IsOne() {
+		t.Errorf("expect 1, but got %s", res)
+	}
+	if res := money.MustParseDecimal("2").PowInt(-1); !res.Equal(money.MustParseDecimal("0.5")) {
+		t.Errorf("expect 0.5, but got %s", res)
+	}
+}
+
+func TestDecimal_ToScaledInt(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  string
+		scale  int32
+		expect int64
+		err    error
+	}{
+		{input: "12.34", scale: 2, expect: 1234},
+		{input: "-12.34", scale: 2, expect: -1234},
+		{input: "12.3", scale: 2, expect: 1230},
+		{input: "12.345", scale: 2, expect: 1235},
+		{input: "12.344", scale: 2, expect: 1234},
+		{input: "12", scale: 0, expect: 12},
+		{input: "0.00", scale: 4, expect: 0},
+		{input: "1200", scale: -2, expect: 12},
+		{input: "92233720368547758.07", scale: 2, expect: 9223372036854775807},
+		{input: "92233720368547758.08", scale: 2, err: money.ErrOverflow},
+		{input: "-92233720368547758.09", scale: 2, err: money.ErrOverflow},
+	}
+
+	for i, test := range table {
+		dec := money.MustParseDecimal(test.input)
+		res, err := dec.ToScaledInt(test.scale)
+		if test.err != err {
+			t.Errorf("#%d - expect error %s, but got %s", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if test.expect != res {
+			t.Errorf("#%d - expect %d, but got %d", i, test.expect, res)
+		}
+
+		back := money.FromScaledInt(res, test.scale)
+		if !dec.Round(test.scale).Equal(back) {
+			t.Errorf("#%d - expect round-trip %s, but got %s", i, dec.Round(test.scale), back)
+		}
+	}
+
+	if s := money.FromScaledInt(1234, 2).String(); s != "12.34" {
+		t.Errorf("expect 12.34, but got %s", s)
+	}
+}
+
+func TestDecimal_Digits(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  string
+		expect []int
+	}{
+		{input: "12345", expect: []int{1, 2, 3, 4, 5}},
+		{input: "-123.45", expect: []int{1, 2, 3, 4, 5}},
+		{input: "0.0012", expect: []int{1, 2}},
+		{input: "1200", expect: []int{1, 2, 0, 0}},
+		{input: "0", expect: []int{0}},
+		{input: "0.00", expect: []int{0}},
+	}
+
+	for i, test := range table {
+		res := money.MustParseDecimal(test.input).Digits()
+		if !reflect.DeepEqual(test.expect, res) {
+			t.Errorf("#%d - expect %v, but got %v", i, test.expect, res)
+		}
+	}
+
+	if res := (money.Decimal{}).Digits(); !reflect.DeepEqual([]int{0}, res) {
+		t.Errorf("expect [0], but got %v", res)
+	}
+}
+
+func TestDecimal_Parts(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input string
+		coef  string
+		exp   int32
+	}{
+		{input: "120.50", coef: "12050", exp: -2},
+		{input: "-0.00000001", coef: "-1", exp: -8},
+		{input: "0.0", coef: "0", exp: -1},
+		{input: "17950000000000.0", coef: "179500000000000", exp: -1},
+	}
+
+	for i, test := range table {
+		x := money.MustParseDecimal(test.input)
+
+		coef, exp := x.Parts()
+		if test.coef != coef.String() {
+			t.Errorf("#%d - expect coefficient %s, but got %s", i, test.coef, coef)
+		}
+		if test.exp != exp {
+			t.Errorf("#%d - expect exponent %d, but got %d", i, test.exp, exp)
+		}
+
+		// Mutating the coefficient must not affect the source
+		coef.SetInt64(42)
+		if x.String() != test.input {
+			t.Errorf("#%d - expect %s to be unchanged, but got %s", i, test.input, x)
+		}
+	}
+}
+
+func TestDecimal_Pow(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		x      string
+		y      string
+		expect string
+		err    error
+	}{
+		{x: "2", y: "10", expect: "1024"},
+		{x: "2", y: "-2", expect: "0.25"},
+		{x: "-2", y: "3", expect: "-8"},
+		{x: "-2", y: "2.0", expect: "4"},
+		{x: "1.05", y: "3", expect: "1.157625"},
+		{x: "2", y: "0.5", expect: "1.4142135623730950"},
+		{x: "8", y: "0.3333333333333333", expect: "1.9999999999999999"},
+		{x: "10", y: "2.5", expect: "316.2277660168379332"},
+		{x: "0.5", y: "-1.5", expect: "2.8284271247461901"},
+		{x: "0", y: "0.5", expect: "0"},
+		{x: "-8", y: "0.5", err: money.ErrNegativeBase},
+		{x: "1", y: "9223372036854775807", expect: "1"},
+		{x: "1", y: "-9223372036854775808", expect: "1"},
+		{x: "1", y: "9223372036854775808", err: money.ErrOverflow},
+		{x: "2", y: "1000000000000000000000000000000", err: money.ErrOverflow},
+		{x: "2", y: "-1000000000000000000000000000000.00", err: money.ErrOverflow},
+	}
+
+	for i, test := range table {
+		x := money.MustParseDecimal(test.x)
+		y := money.MustParseDecimal(test.y)
+
+		res, err := x.Pow(y)
+		if test.err != err {
+			t.Errorf("#%d - expect error %s, but got %s", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		expect := money.MustParseDecimal(test.expect)
+		if !expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, expect, res)
+		}
+	}
+}
+
+func TestDecimal_Cbrt(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  string
+		expect string
+	}{
+		{input: "27", expect: "3"},
+		{input: "-8", expect: "-2"},
+		{input: "0", expect: "0"},
+		{input: "1", expect: "1"},
+		{input: "0.001", expect: "0.1"},
+		{input: "2", expect: "1.2599210498948732"},
+		{input: "-2", expect: "-1.2599210498948732"},
+		{input: "1000000000000000000000000000", expect: "1000000000"},
+		{input: "0.000000000000000000000000002", expect: "0.0000000012599210"},
+		{input: "123.456", expect: "4.9793279846740481"},
+	}
+
+	for i, test := range table {
+		res, err := money.MustParseDecimal(test.input).Cbrt()
+		if err != nil {
+			t.Fatalf("#%d - %s", i, err)
+		}
+		if expect := money.MustParseDecimal(test.expect); !expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, expect, res)
+		}
+	}
+}
+
+func TestPow10(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  string
+		expect string
+	}{
+		{input: "0", expect: "1"},
+		{input: "3", expect: "1000"},
+		{input: "-2", expect: "0.01"},
+		{input: "0.5", expect: "3.1622776601683793"},
+	}
+
+	for i, test := range table {
+		res := money.Pow10(money.MustParseDecimal(test.input))
+		expect := money.MustParseDecimal(test.expect)
+		if !expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, expect, res)
+		}
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expect Pow10 to panic with an exponent beyond int64")
+			}
+		}()
+		money.Pow10(money.MustParseDecimal("1000000000000000000000000000000"))
+	}()
+}
+
+func TestDecimal_GoString(t *testing.T) {
+	t.Parallel()
+
+	re := regexp.MustCompile(`^money\.MustParseDecimal\("(.*)"\)$`)
+	table := []struct {
+		input  string
+		expect string
+	}{
+		{input: "120.50", expect: `money.MustParseDecimal("120.50")`},
+		{input: "-1.0", expect: `money.MustParseDecimal("-1.0")`},
+		{input: "0.00000001", expect: `money.MustParseDecimal("0.00000001")`},
+	}
+
+	for i, test := range table {
+		x := money.MustParseDecimal(test.input)
+		res := fmt.Sprintf("%#v", x)
+		if test.expect != res {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, res)
+		}
+
+		m := re.FindStringSubmatch(res)
+		if m == nil {
+			t.Fatalf("#%d - cannot parse %s", i, res)
+		}
+		if back := money.MustParseDecimal(m[1]); back.String() != x.String() {
+			t.Errorf("#%d - expect %s to re-parse to %s, but got %s", i, res, x, back)
+		}
+	}
+}
+
+func TestDecimal_BinarySize(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input string
+	}{
+		{input: "0"},
+		{input: "0.00"},
+		{input: "1.0"},
+		{input: "-1.0"},
+		{input: "255"},
+		{input: "256"},
+		{input: "-65536"},
+		{input: "0.00000001"},
+		{input: "17950000000000.0"},
+		{input: "-123456789012345678901234567890.123456789"},
+	}
+
+	for i, test := range table {
+		x := money.MustParseDecimal(test.input)
+
+		data, err := x.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data) != x.BinarySize() {
+			t.Errorf("#%d - expect %d, but got %d - %s", i, len(data), x.BinarySize(), test.input)
+		}
+	}
+}
+
+func TestDecimal_SetAdd(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input decPair
+	}{
+		{input: decPair{X: "1.0", Y: "1.0"}},
+		{input: decPair{X: "1.0", Y: "-1.0"}},
+		{input: decPair{X: "-1.0", Y: "-1.0"}},
+		{input: decPair{X: "1.0", Y: "0.0001"}},
+		{input: decPair{X: "0.0001", Y: "1"}},
+		{input: decPair{X: "2454495034.0", Y: "3451204593.0"}},
+		{input: decPair{X: "24544.95034", Y: "0.3451204593"}},
+		{input: decPair{X: "17950000000000", Y: "0.00000000000000000000001"}},
+	}
+
+	for i, test := range table {
+		x := money.MustParseDecimal(test.input.X)
+		y := money.MustParseDecimal(test.input.Y)
+
+		var res money.Decimal
+		res.SetAdd(x, y)
+		expect := x.Add(y)
+		if expect.String() != res.String() {
+			t.Errorf("#%d - expect %s, but got %s", i, expect, res)
+		}
+	}
+}
+
+func TestDecimal_SetAdd_Accumulate(t *testing.T) {
+	t.Parallel()
+
+	values := []string{"1.5", "-0.25", "100", "0.001", "-42.42", "7"}
+
+	var sum money.Decimal
+	expect := money.MustParseDecimal("0")
+	for _, v := range values {
+		x := money.MustParseDecimal(v)
+		sum.SetAdd(sum, x)
+		expect = expect.Add(x)
+	}
+	if expect.String() != sum.String() {
+		t.Errorf("expect %s, but got %s", expect, sum)
+	}
+}
+
+func TestDecimal_MulDiv(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input   string
+		num     string
+		den     string
+		prec    int32
+		expect  string
+		chained string
+		err     error
+	}{
+		{input: "100", num: "1", den: "3", prec: 2, expect: "33.33", chained: "33.33"},
+		{input: "-100", num: "2", den: "3", prec: 2, expect: "-66.67", chained: "-66.67"},
+		// The chained division rounds to DivisionPrecision first
+		{input: "1", num: "1", den: "200.0000000000000001", prec: 2, expect: "0", chained: "0.01"},
+		{input: "0.5", num: "1", den: "100.00000000000000001", prec: 2, expect: "0", chained: "0.01"},
+		{input: "3", num: "1.37", den: "1.0000000000000000003", prec: 3, expect: "4.11", chained: "4.11"},
+		{input: "1", num: "1", den: "0", prec: 2, err: money.ErrDivisionByZero},
+	}
+
+	for i, test := range table {
+		x := money.MustParseDecimal(test.input)
+		num := money.MustParseDecimal(test.num)
+		den := money.MustParseDecimal(test.den)
+
+		res, err := x.MulDiv(num, den, test.prec)
+		if test.err != err {
+			t.Errorf("#%d - expect error %v, but got %v", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if expect := money.MustParseDecimal(test.expect); !expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, expect, res)
+		}
+		if chained := x.Mul(num).Div(den).Round(test.prec); !money.MustParseDecimal(test.chained).Equal(chained) {
+			t.Errorf("#%d - expect chained %s, but got %s", i, test.chained, chained)
+		}
+	}
+}
+
+func TestDecimal_MulPow10(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  string
+		n      int32
+		expect string
+	}{
+		{input: "1.25", n: 3, expect: "1250"},
+		{input: "1.25", n: 2, expect: "125"},
+		{input: "1.25", n: 0, expect: "1.25"},
+		{input: "1.25", n: -2, expect: "0.0125"},
+		{input: "-120.50", n: 1, expect: "-1205"},
+		{input: "0", n: 5, expect: "0"},
+		{input: "17950000000000.12", n: -16, expect: "0.001795000000000012"},
+	}
+
+	for i, test := range table {
+		x := money.MustParseDecimal(test.input)
+		res := x.MulPow10(test.n)
+		if expect := money.MustParseDecimal(test.expect); !expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, expect, res)
+		}
+		if general := x.Mul(money.Pow10(money.MustParseDecimal(strconv.Itoa(int(test.n))))); !general.Equal(res) {
+			t.Errorf("#%d - expect %s to equal Mul(Pow10(%d)) %s", i, res, test.n, general)
+		}
+	}
+}
+
+func BenchmarkDecimal_MulPow10(b *testing.B) {
+	x := money.MustParseDecimal("120.50")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		x.MulPow10(3)
+	}
+}
+
+func BenchmarkDecimal_MulThousand(b *testing.B) {
+	x := money.MustParseDecimal("120.50")
+	thousand := money.MustParseDecimal("1000")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		x.Mul(thousand)
+	}
+}
+
+func BenchmarkDecimal_Add(b *testing.B) {
+	x := money.MustParseDecimal("1.23")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sum := money.MustParseDecimal("0.00")
+		for k := 0; k < 1000; k++ {
+			sum = sum.Add(x)
+		}
+	}
+}
+
+func BenchmarkDecimal_SetAdd(b *testing.B) {
+	x := money.MustParseDecimal("1.23")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sum := money.MustParseDecimal("0.00")
+		for k := 0; k < 1000; k++ {
+			sum.SetAdd(sum, x)
+		}
+	}
+}
+
+func TestDecimal_MulRound(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  decPair
+		prec   int32
+		expect string
+	}{
+		{input: decPair{X: "1.005", Y: "1.005"}, prec: 4, expect: "1.0100"},
+		{input: decPair{X: "1.005", Y: "1.005"}, prec: 6, expect: "1.010025"},
+		{input: decPair{X: "-2.5", Y: "0.333"}, prec: 2, expect: "-0.83"},
+		{input: decPair{X: "120", Y: "0.077"}, prec: 2, expect: "9.24"},
+	}
+
+	for i, test := range table {
+		x := money.MustParseDecimal(test.input.X)
+		y := money.MustParseDecimal(test.input.Y)
+
+		res := x.MulRound(y, test.prec)
+		if test.expect != res.String() {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, res)
+		}
+	}
+}
+
+func TestDecimal_MulRound_Repeated(t *testing.T) {
+	t.Parallel()
+
+	rate := money.MustParseDecimal("1.0123456")
+	rounded := money.MustParseDecimal("1")
+	product := money.MustParseDecimal("1")
+	for i := 1; i <= 10; i++ {
+		rounded = rounded.MulRound(rate, 8)
+		product = product.Mul(rate)
+
+		if rounded.Exponent() != -8 {
+			t.Errorf("#%d - expect MulRound exponent -8, but got %d", i, rounded.Exponent())
+		}
+		if product.Exponent() != int32(-7*i) {
+			t.Errorf("#%d - expect Mul exponent %d, but got %d", i, -7*i, product.Exponent())
+		}
+	}
+
+	diff := rounded.Sub(product).Abs()
+	if diff.Cmp(money.MustParseDecimal("0.0000001")) > 0 {
+		t.Errorf("expect %s to be close to %s", rounded, product)
+	}
+}
+
+func BenchmarkParseDecimal(b *testing.B) {
+	data := "17950000000000.12"
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := money.ParseDecimal(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecimal_SetString(b *testing.B) {
+	data := "17950000000000.12"
+
+	var d money.Decimal
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := d.SetString(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseDecimalBytes(b *testing.B) {
+	data := []byte("17950000000000.12")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := money.ParseDecimalBytes(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestParseDecimal_MaxDigits(t *testing.T) {
+	t.Parallel()
+
+	maxDigits := money.GetConfig().MaxDecimalDigits
+
+	table := []struct {
+		input string
+		err   error
+	}{
+		{input: strings.Repeat("9", maxDigits)},
+		{input: "-" + strings.Repeat("1", maxDigits/2) + "." + strings.Repeat("1", maxDigits/2)},
+		{input: strings.Repeat("9", maxDigits+1), err: money.ErrInvalidDecimal},
+		{input: "0." + strings.Repeat("0", maxDigits), err: money.ErrInvalidDecimal},
+		{input: strings.Repeat("1", 1000000), err: money.ErrInvalidDecimal},
+	}
+
+	for i, test := range table {
+		if _, err := money.ParseDecimal(test.input); test.err != err {
+			t.Errorf("#%d - expect error %v, but got %v", i, test.err, err)
+		}
+		if _, err := money.ParseDecimalBytes([]byte(test.input)); test.err != err {
+			t.Errorf("#%d - expect error %v, but got %v", i, test.err, err)
+		}
+	}
+
+	// Exponent notation counts the exponent as well
+	exponents := []struct {
+		input string
+		err   error
+	}{
+		{input: "1e" + strconv.Itoa(maxDigits-1)},
+		{input: "1e-" + strconv.Itoa(maxDigits-1)},
+		{input: "1e" + strconv.Itoa(maxDigits), err: money.ErrInvalidDecimal},
+		{input: "1e-" + strconv.Itoa(maxDigits), err: money.ErrInvalidDecimal},
+		{input: "12.5e" + strconv.Itoa(maxDigits-1), err: money.ErrInvalidDecimal},
+		{input: "1e999999999", err: money.ErrInvalidDecimal},
+	}
+	for i, test := range exponents {
+		if _, err := money.FromShopspring(test.input); test.err != err {
+			t.Errorf("#%d - expect error %v, but got %v", i, test.err, err)
+		}
+		var d money.Decimal
+		if err := d.UnmarshalJSON([]byte(test.input)); (test.err != nil) != (err != nil) {
+			t.Errorf("#%d - expect JSON error %v, but got %v", i, test.err, err)
+		}
+	}
+}
+
+func TestScanDecimals(t *testing.T) {
+	t.Parallel()
+
+	src := [][]byte{
+		[]byte("120.50"),
+		[]byte("-0.001"),
+		[]byte("17950000000000.12"),
+		[]byte("12a.5"),
+		[]byte("1"),
+	}
+	dest := make([]*money.Decimal, len(src))
+	for i := range dest {
+		dest[i] = new(money.Decimal)
+	}
+
+	err := money.ScanDecimals(dest, src)
+	if !errors.Is(err, money.ErrInvalidDecimal) {
+		t.Fatalf("expect error %s, but got %v", money.ErrInvalidDecimal, err)
+	}
+	if !strings.Contains(err.Error(), "cell 3") {
+		t.Errorf("expect error to report cell 3, but got %s", err)
+	}
+	for i, expect := range []string{"120.50", "-0.001", "17950000000000.12"} {
+		if res := dest[i]; !money.MustParseDecimal(expect).Equal(*res) {
+			t.Errorf("#%d - expect %s, but got %s", i, expect, res)
+		}
+	}
+	if !dest[4].IsZero() {
+		t.Errorf("expect values after the malformed cell to be untouched, but got %s", dest[4])
+	}
+
+	src[3] = []byte("125.5")
+	if err := money.ScanDecimals(dest, src); err != nil {
+		t.Fatal(err)
+	}
+	for i, b := range src {
+		var expect money.Decimal
+		if err := expect.Scan(b); err != nil {
+			t.Fatal(err)
+		}
+		if !expect.Equal(*dest[i]) {
+			t.Errorf("#%d - expect %s, but got %s", i, expect, dest[i])
+		}
+	}
+
+	if err := money.ScanDecimals(dest[:1], src); err != money.ErrLengthMismatch {
+		t.Errorf("expect error %s, but got %v", money.ErrLengthMismatch, err)
+	}
+}
+
+func TestDecimal_Scan(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  interface{}
+		expect string
+		err    error
+	}{
+		{input: []byte("120.50"), expect: "120.50"},
+		{input: "-0.001", expect: "-0.001"},
+		{input: int64(120), expect: "120"},
+		{input: 0.5, expect: "0.5"},
+		{input: []byte("abc"), err: money.ErrInvalidDecimal},
+		{input: nil, err: money.ErrInvalidDecimal},
+		{input: true, err: money.ErrInvalidDecimal},
+	}
+
+	for i, test := range table {
+		var res money.Decimal
+		err := res.Scan(test.input)
+		if test.err != nil || err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("#%d - expect error %v, but got %v - %v", i, test.err, err, test.input)
+			}
+			continue
+		}
+		if !money.MustParseDecimal(test.expect).Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s - %v", i, test.expect, res, test.input)
+		}
+	}
+}
+
+func benchmarkScanRows(n int) [][]byte {
+	r := rand.New(rand.NewSource(1))
+	rows := make([][]byte, n)
+	for i := range rows {
+		rows[i] = []byte(strconv.FormatInt(r.Int63n(1e12), 10) + "." + strconv.Itoa(r.Intn(100)))
+	}
+	return rows
+}
+
+func BenchmarkScanDecimals(b *testing.B) {
+	src := benchmarkScanRows(1000)
+	dest := make([]*money.Decimal, len(src))
+	for i := range dest {
+		dest[i] = new(money.Decimal)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := money.ScanDecimals(dest, src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecimal_Scan(b *testing.B) {
+	src := benchmarkScanRows(1000)
+	dest := make([]*money.Decimal, len(src))
+	for i := range dest {
+		dest[i] = new(money.Decimal)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j, v := range src {
+			var scanner interface{ Scan(interface{}) error } = dest[j]
+			if err := scanner.Scan(v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkDecimal_UnmarshalJSON(b *testing.B) {
+	data := []byte(`"17950000000000.12"`)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var d money.Decimal
+		if err := d.UnmarshalJSON(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestDecimal_RatString(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  string
+		expect string
+	}{
+		{input: "1.23", expect: "123/100"},
+		{input: "0.5", expect: "1/2"},
+		{input: "-0.75", expect: "-3/4"},
+		{input: "120.00", expect: "120"},
+		{input: "0", expect: "0"},
+		{input: "0.00000001", expect: "1/100000000"},
+	}
+
+	for i, test := range table {
+		if res := money.MustParseDecimal(test.input).RatString(); test.expect != res {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, res)
+		}
+	}
+
+	third := money.DecimalOne().Div(money.MustParseDecimal("3"))
+	if expect := "3333333333333333/10000000000000000"; expect != third.RatString() {
+		t.Errorf("expect %s, but got %s", expect, third.RatString())
+	}
+}
+
+func TestDecimal_OrderedBytes(t *testing.T) {
+	t.Parallel()
+
+	inputs := []string{
+		"0", "0.00", "-0.0", "1", "1.0", "1.5", "1.05", "1.50", "10", "9.99", "100",
+		"120.50", "0.1", "0.15", "0.09", "0.000001", "12345678901234567890.123456789",
+		"-1", "-1.5", "-1.05", "-10", "-9.99", "-0.1", "-0.15", "-0.09", "-0.000001",
+		"-12345678901234567890.123456789", "1e-900", "-1e-900",
+	}
+	r := rand.New(rand.NewSource(42))
+	ds := make([]money.Decimal, 0, len(inputs)+100)
+	for _, input := range inputs {
+		d, err := money.FromShopspring(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ds = append(ds, d)
+	}
+	ds = append(ds, randomDecimals(r, 100)...)
+	r.Shuffle(len(ds), func(i, j int) { ds[i], ds[j] = ds[j], ds[i] })
+
+	for i, d := range ds {
+		res, err := money.DecimalFromOrderedBytes(d.OrderedBytes())
+		if err != nil {
+			t.Fatalf("#%d - expect no error, but got %s - %s", i, err, d)
+		}
+		if !d.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, d, res)
+		}
+
+		for k, d2 := range ds {
+			expect := d.Cmp(d2)
+			if res := bytes.Compare(d.OrderedBytes(), d2.OrderedBytes()); expect != res {
+				t.Errorf("#%d.%d - expect %s vs %s to compare %d, but got %d", i, k, d, d2, expect, res)
+			}
+		}
+	}
+
+	for i, data := range [][]byte{
+		nil,
+		{0x00},
+		{0x02, 0x00},
+		{0x03, 0x80},
+		{0x03, 0x80, 0, 0, 0, 0, 0, 0, 0, '1'},
+		{0x03, 0x80, 0, 0, 0, 0, 0, 0, 0, 'a', 0x00},
+		{0x03, 0x80, 0, 0, 0, 0, 0, 0, 0, '0', 0x00},
+	} {
+		if _, err := money.DecimalFromOrderedBytes(data); err != money.ErrInvalidDecimal {
+			t.Errorf("#%d - expect error %s, but got %v", i, money.ErrInvalidDecimal, err)
+		}
+	}
+}
+
+func TestDecimal_Shopspring(t *testing.T) {
+	t.Parallel()
+
+	// Known shopspring/decimal String() and NewFromString forms
+	table := []struct {
+		input      string
+		expect     string
+		shopspring string
+	}{
+		{input: "0", expect: "0.0", shopspring: "0"},
+		{input: "120", expect: "120.0", shopspring: "120"},
+		{input: "120.5", expect: "120.5", shopspring: "120.5"},
+		{input: "120.50", expect: "120.50", shopspring: "120.5"},
+		{input: "-0.001", expect: "-0.001", shopspring: "-0.001"},
+		{input: "12345678901234567890.123456789", expect: "12345678901234567890.123456789", shopspring: "12345678901234567890.123456789"},
+		{input: "1.205e2", expect: "120.5", shopspring: "120.5"},
+		{input: "1E-3", expect: "0.001", shopspring: "0.001"},
+		{input: "-5e3", expect: "-5000.0", shopspring: "-5000"},
+	}
+
+	for i, test := range table {
+		res, err := money.FromShopspring(test.input)
+		if err != nil {
+			t.Fatalf("#%d - expect no error, but got %s - %s", i, err, test.input)
+		}
+		if !money.MustParseDecimal(test.expect).Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s - %s", i, test.expect, res, test.input)
+		}
+		if s := res.ShopspringString(); test.shopspring != s {
+			t.Errorf("#%d - expect shopspring string %s, but got %s - %s", i, test.shopspring, s, test.input)
+		}
+
+		// shopspring MarshalJSON output, with and without quotes
+		for _, data := range []string{`"` + test.shopspring + `"`, test.shopspring} {
+			var d money.Decimal
+			if err := json.Unmarshal([]byte(data), &d); err != nil {
+				t.Fatalf("#%d - expect no error, but got %s - %s", i, err, data)
+			}
+			if !res.Equal(d) {
+				t.Errorf("#%d - expect %s, but got %s - %s", i, res, d, data)
+			}
+		}
+	}
+
+	for i, input := range []string{"", "abc", "1.2.3", "1e", "0x10", "Inf", "1e100000000", "1e-100000000"} {
+		if _, err := money.FromShopspring(input); err != money.ErrInvalidDecimal {
+			t.Errorf("#%d - expect error %s, but got %v - %s", i, money.ErrInvalidDecimal, err, input)
+		}
+	}
+}
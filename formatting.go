@@ -1,11 +1,43 @@
 package money
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
 
 	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
 )
 
+// ErrAmbiguousCurrencySymbol indicates that a currency symbol is used by
+// several currencies and the locale does not tell which one is meant
+var ErrAmbiguousCurrencySymbol = errors.New("ambiguous currency symbol")
+
+// symbolCurrencies maps the common currency symbols to the currencies using
+// them. The locale picks one when there are several candidates.
+var symbolCurrencies = map[string][]Currency{
+	"$":   {"USD", "CAD", "AUD", "NZD", "MXN", "SGD", "HKD"},
+	"US$": {"USD"},
+	"CA$": {"CAD"},
+	"A$":  {"AUD"},
+	"€":   {"EUR"},
+	"£":   {"GBP"},
+	"¥":   {"JPY", "CNY"},
+	"₹":   {"INR"},
+	"₩":   {"KRW"},
+	"₽":   {"RUB"},
+	"₺":   {"TRY"},
+	"₪":   {"ILS"},
+	"zł":  {"PLN"},
+}
+
 // CurrencyFormatter decorates a given number with formatting options.
 type CurrencyFormatter = currency.Formatter
 
@@ -20,20 +52,80 @@ var (
 	FormatterISO = currency.ISO
 )
 
-// Formatter formats Money to its string representation
+// Formatter formats Money to its string representation. It is safe for
+// concurrent use, but must not be copied nor modified after first use.
 type Formatter struct {
 	CurrencyFormater CurrencyFormatter
 	Rounding         RoundingKind
+	// SymbolOverride replaces the symbol of the given currencies. This is also
+	// the only way to format unofficial currencies, which have no x/text
+	// symbol.
+	SymbolOverride map[Currency]string
+
+	// fns memoizes the currency.Formatter of each currency
+	fns sync.Map
 }
 
 // Wrap decorates x with the formating preferences
 func (f *Formatter) Wrap(x *Money) fmt.Formatter {
+	if symbol, ok := f.SymbolOverride[x.Currency]; ok {
+		return symbolValue{
+			symbol: symbol,
+			amount: x.Amount.stringFixed(f.scale(x)),
+		}
+	}
+
+	// The amount is rounded and converted to a string in decimal, since a
+	// float64 cannot hold large amounts exactly
+	return f.formatter(x.Currency)(fixedValue(x.Amount.stringFixed(f.scale(x))))
+}
+
+// formatter returns the currency.Formatter of c. It is built on first use and
+// then reused, so the formatting options must not change after the first call
+// to Wrap.
+func (f *Formatter) formatter(c Currency) CurrencyFormatter {
+	if fn, ok := f.fns.Load(c); ok {
+		return fn.(CurrencyFormatter)
+	}
 	fn := f.CurrencyFormater.Default(
-		*x.Currency.currency(),
+		*c.currency(),
 	).Kind(
 		currency.Kind(f.Rounding.kind()),
 	)
-	return fn(x.Amount.Float64())
+	f.fns.Store(c, fn)
+	return fn
+}
+
+// scale returns the number of fraction digits to display for x. Unofficial
+// currencies keep the precision of the amount.
+func (f *Formatter) scale(x *Money) int32 {
+	if _, ok := x.Currency.unoficial(); ok {
+		return int32(x.Amount.roundPrec())
+	}
+	scale, _ := currency.Kind(f.Rounding.kind()).Rounding(*x.Currency.currency())
+	return int32(scale)
+}
+
+// symbolValue is a formatted amount preceded by a custom symbol
+type symbolValue struct {
+	symbol string
+	amount string
+}
+
+// Format implements the fmt.Formatter interface
+func (v symbolValue) Format(s fmt.State, verb rune) {
+	io.WriteString(s, v.symbol)
+	io.WriteString(s, " ")
+	io.WriteString(s, v.amount)
+}
+
+// fixedValue is an amount already rounded and formatted with a fixed number of
+// fraction digits. It is written as is, whatever the verb and precision.
+type fixedValue string
+
+// Format implements the fmt.Formatter interface
+func (v fixedValue) Format(s fmt.State, verb rune) {
+	io.WriteString(s, string(v))
 }
 
 // DecimalFormatter formats Decimal to its string representation
@@ -45,10 +137,241 @@ type DecimalFormatter struct {
 
 // Wrap decorates x with the formating preferences
 func (f *DecimalFormatter) Wrap(x *Decimal) fmt.Formatter {
+	kind := currency.Kind(f.Rounding.kind())
 	fn := f.CurrencyFormater.Default(
 		*f.Currency.currency(),
-	).Kind(
-		currency.Kind(f.Rounding.kind()),
-	)
-	return fn(x.Float64())
+	).Kind(kind)
+	scale, _ := kind.Rounding(*f.Currency.currency())
+	return fn(fixedValue(x.stringFixed(int32(scale))))
+}
+
+// localeValue is a decimal formatted for the language of the fmt.State. The
+// grouping, digits and affixes come from x/text, but the value itself never
+// goes through a float64.
+type localeValue struct {
+	// digits is the absolute value in ASCII digits, with a '.' radix point
+	digits  string
+	neg     bool
+	percent bool
+}
+
+// Format implements the fmt.Formatter interface
+func (v localeValue) Format(s fmt.State, verb rune) {
+	tag := language.Und
+	if ls, ok := s.(interface{ Language() language.Tag }); ok {
+		tag = ls.Language()
+	}
+	p := message.NewPrinter(tag)
+
+	// The affixes surround the digits of one, e.g. "-1" or "-100 %"
+	one := int64(1)
+	if v.neg {
+		one = -1
+	}
+	sample, ref := p.Sprint(number.Decimal(one)), p.Sprint(number.Decimal(int64(1)))
+	if v.percent {
+		sample, ref = p.Sprint(number.Percent(one)), p.Sprint(number.Decimal(int64(100)))
+	}
+	prefix, suffix := "", ""
+	if i := strings.Index(sample, ref); i >= 0 {
+		prefix, suffix = sample[:i], sample[i+len(ref):]
+	} else if v.neg {
+		prefix = "-"
+	}
+
+	intDigits, fracDigits := v.digits, ""
+	if i := strings.IndexByte(v.digits, '.'); i >= 0 {
+		intDigits, fracDigits = v.digits[:i], v.digits[i+1:]
+	}
+	zero := []rune(p.Sprint(number.Decimal(int64(0))))[0]
+
+	io.WriteString(s, prefix)
+	if i, ok := new(big.Int).SetString(intDigits, 10); ok && i.IsInt64() {
+		io.WriteString(s, p.Sprint(number.Decimal(i.Int64())))
+	} else {
+		io.WriteString(s, groupDigits(localDigits(intDigits, zero), tag))
+	}
+	if fracDigits != "" {
+		_, radix := separators(tag)
+		io.WriteString(s, string(radix))
+		io.WriteString(s, localDigits(fracDigits, zero))
+	}
+	io.WriteString(s, suffix)
+}
+
+// localDigits maps the ASCII digits of s to the digits starting at zero
+func localDigits(s string, zero rune) string {
+	if zero == '0' {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		return zero + (r - '0')
+	}, s)
+}
+
+// groupDigits inserts the group separator of tag into the integer digits s,
+// for integers beyond int64 which cannot go through x/text. The group sizes are
+// read from a formatted sample, since they vary by locale (e.g. 12,34,56,789
+// in hi).
+func groupDigits(s string, tag language.Tag) string {
+	group, _ := separators(tag)
+	sample := message.NewPrinter(tag).Sprint(number.Decimal(int64(1234567890123456789)))
+	groups := strings.Split(sample, string(group))
+	if len(groups) < 3 {
+		return s
+	}
+	primary := utf8.RuneCountInString(groups[len(groups)-1])
+	secondary := utf8.RuneCountInString(groups[len(groups)-2])
+
+	r := []rune(s)
+	var b strings.Builder
+	next := len(r) - primary
+	var marks []int
+	for next > 0 {
+		marks = append(marks, next)
+		next -= secondary
+	}
+	for i, c := range r {
+		if len(marks) > 0 && i == marks[len(marks)-1] {
+			b.WriteRune(group)
+			marks = marks[:len(marks)-1]
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// FormatParts returns the integer (major) and fractional (minor) parts of x
+// rounded to the currency scale, so they can be rendered separately (e.g.
+// superscript cents). The major part is grouped according to tag and carries
+// the sign. The minor part is empty for currencies without decimals.
+//
+//   e.g. 1234.50 USD en	-> "1,234", "50"
+//   e.g. 120 JPY en		-> "120", ""
+func (x *Money) FormatParts(tag language.Tag) (major, minor string) {
+	scale := int32(x.Currency.Scale())
+	amount := x.Currency.round(x.Amount).rescale(-scale)
+
+	abs := amount.Abs()
+	digits := abs.value.String()
+	if n := int(scale) + 1 - len(digits); n > 0 {
+		digits = strings.Repeat("0", n) + digits
+	}
+	intDigits := digits[:len(digits)-int(scale)]
+	minor = digits[len(digits)-int(scale):]
+
+	if i := abs.rescale(0).value; i.IsInt64() {
+		major = message.NewPrinter(tag).Sprint(number.Decimal(i.Int64()))
+	} else {
+		major = groupDigits(intDigits, tag)
+	}
+	if amount.Sign() == SignNegative {
+		major = "-" + major
+	}
+	return major, minor
+}
+
+// AmountString returns the amount of x rounded to the currency scale, without
+// currency nor grouping, such as to populate an editable input field. Unlike
+// Decimal.String, it always shows the currency scale.
+//
+//   e.g. 120.5 USD	-> 120.50
+//   e.g. 120.4 JPY	-> 120
+func (x *Money) AmountString() string {
+	return x.Amount.stringFixed(int32(x.Currency.Scale()))
+}
+
+// ParseWithSymbol parses s, an amount preceded or followed by a currency
+// symbol or ISO code, as formatted for tag. It is the inverse of
+// FormatterSymbol. Group separators are stripped, and the decimal separator of
+// tag is used as radix point.
+// A symbol shared by several currencies is resolved with the currency of tag.
+// It returns ErrAmbiguousCurrencySymbol when tag is language.Und or does not
+// use any of the candidates.
+//
+//   e.g. $120.50 en-US	-> 120.50 USD
+//   e.g. €1.000,00 de	-> 1000.00 EUR
+func ParseWithSymbol(s string, tag language.Tag) (*Money, error) {
+	s = strings.TrimSpace(s)
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+
+	amount := strings.TrimLeftFunc(s, isSymbolRune)
+	symbol := s[:len(s)-len(amount)]
+	if symbol == "" {
+		amount = strings.TrimRightFunc(s, isSymbolRune)
+		symbol = s[len(amount):]
+	}
+	symbol = strings.TrimSpace(symbol)
+	amount = strings.TrimSpace(amount)
+
+	c, err := symbolCurrency(symbol, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	group, radix := separators(tag)
+	amount = strings.Map(func(r rune) rune {
+		switch {
+		case r == group, unicode.IsSpace(group) && unicode.IsSpace(r):
+			return -1
+		case r == radix:
+			return '.'
+		}
+		return r
+	}, amount)
+	if neg {
+		amount = "-" + amount
+	}
+
+	a, err := ParseDecimal(amount)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMagnitude(a); err != nil {
+		return nil, err
+	}
+	return NewMoney(a, c), nil
+}
+
+// isSymbolRune reports whether r can be part of a currency symbol
+func isSymbolRune(r rune) bool {
+	return !unicode.IsDigit(r) && !unicode.IsSpace(r) && r != '-' && r != '+'
+}
+
+// symbolCurrency returns the currency matching symbol for tag
+func symbolCurrency(symbol string, tag language.Tag) (Currency, error) {
+	candidates, ok := symbolCurrencies[symbol]
+	if !ok {
+		return ParseCurrency(symbol)
+	}
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+	if tag == language.Und {
+		return nullCurrency, ErrAmbiguousCurrencySymbol
+	}
+
+	u, conf := currency.FromTag(tag)
+	if conf == language.No {
+		return nullCurrency, ErrAmbiguousCurrencySymbol
+	}
+	for _, c := range candidates {
+		if c.String() == u.String() {
+			return c, nil
+		}
+	}
+	return nullCurrency, ErrAmbiguousCurrencySymbol
+}
+
+// separators returns the group and decimal separators used by tag
+func separators(tag language.Tag) (group, radix rune) {
+	// 1234.5 is formatted as 1<group>234<radix>5
+	r := []rune(message.NewPrinter(tag).Sprint(number.Decimal(1234.5)))
+	if len(r) < 7 {
+		return ',', r[len(r)-2]
+	}
+	return r[1], r[len(r)-2]
 }
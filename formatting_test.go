@@ -1,6 +1,8 @@
 package money_test
 
 import (
+	"errors"
+	"sync"
 	"testing"
 
 	"github.com/deixis/money"
@@ -156,6 +158,18 @@ func TestMoney_Format(t *testing.T) {
 			lang:      language.Chinese,
 			expect:    "JPY -100",
 		},
+		{
+			input:     money.MustParse("12345678901234567.89", "CHF"),
+			formatter: iso,
+			lang:      language.English,
+			expect:    "CHF 12345678901234567.89",
+		},
+		{
+			input:     money.MustParse("-12345678901234567.885", "CHF"),
+			formatter: symbol,
+			lang:      language.English,
+			expect:    "CHF -12345678901234567.89",
+		},
 	}
 
 	for i, test := range table {
@@ -169,3 +183,239 @@ func TestMoney_Format(t *testing.T) {
 		}
 	}
 }
+
+func TestDecimalFormatter_Wrap(t *testing.T) {
+	t.Parallel()
+
+	f := &money.DecimalFormatter{
+		CurrencyFormater: money.FormatterISO,
+		Currency:         "USD",
+		Rounding:         money.RoundingStandard,
+	}
+
+	table := []struct {
+		input  money.Decimal
+		expect string
+	}{
+		{input: money.MustParseDecimal("120.5"), expect: "USD 120.50"},
+		{input: money.MustParseDecimal("-100.009"), expect: "USD -100.01"},
+		{input: money.MustParseDecimal("17950000000000.005"), expect: "USD 17950000000000.01"},
+		{input: money.MustParseDecimal("12345678901234567.89"), expect: "USD 12345678901234567.89"},
+	}
+
+	p := message.NewPrinter(language.English)
+	for i, test := range table {
+		res := p.Sprintf("%f", f.Wrap(&test.input))
+
+		if test.expect != res {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, res)
+		}
+	}
+}
+
+func TestMoney_Format_Memoized(t *testing.T) {
+	t.Parallel()
+
+	shared := &money.Formatter{
+		CurrencyFormater: money.FormatterSymbol,
+		Rounding:         money.RoundingStandard,
+	}
+	inputs := []*money.Money{
+		money.MustParse("120.01", "CHF"),
+		money.MustParse("1.0", "EUR"),
+		money.MustParse("-100.009", "CNY"),
+		money.MustParse("-100.009", "JPY"),
+		money.MustParse("1000000.001", "USD"),
+	}
+
+	p := message.NewPrinter(language.English)
+	expect := make([]string, len(inputs))
+	for i, input := range inputs {
+		fresh := &money.Formatter{
+			CurrencyFormater: money.FormatterSymbol,
+			Rounding:         money.RoundingStandard,
+		}
+		expect[i] = p.Sprintf("%f", fresh.Wrap(input))
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			p := message.NewPrinter(language.English)
+			for n := 0; n < 10; n++ {
+				for i, input := range inputs {
+					if res := p.Sprintf("%f", shared.Wrap(input)); expect[i] != res {
+						t.Errorf("#%d - expect %s, but got %s", i, expect[i], res)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkFormatter_Wrap(b *testing.B) {
+	f := &money.Formatter{
+		CurrencyFormater: money.FormatterSymbol,
+		Rounding:         money.RoundingStandard,
+	}
+	m := money.MustParse("120.50", "CHF")
+	p := message.NewPrinter(language.English)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Sprintf("%f", f.Wrap(m))
+	}
+}
+
+func TestMoney_Format_SymbolOverride(t *testing.T) {
+	t.Parallel()
+
+	// The currency registry is global, so use a code no other test relies on
+	money.RegisterUnoficialCurrency("XOVR")
+
+	overrides := map[money.Currency]string{
+		money.MustParseCurrency("EUR"):  "EUR€",
+		money.MustParseCurrency("XOVR"): "Ð",
+	}
+	iso := &money.Formatter{
+		CurrencyFormater: money.FormatterISO,
+		Rounding:         money.RoundingStandard,
+		SymbolOverride:   overrides,
+	}
+	symbol := &money.Formatter{
+		CurrencyFormater: money.FormatterSymbol,
+		Rounding:         money.RoundingStandard,
+		SymbolOverride:   overrides,
+	}
+
+	table := []struct {
+		input     *money.Money
+		formatter *money.Formatter
+		expect    string
+	}{
+		{input: money.MustParse("1.0", "EUR"), formatter: iso, expect: "EUR€ 1.00"},
+		{input: money.MustParse("1.005", "EUR"), formatter: symbol, expect: "EUR€ 1.01"},
+		{input: money.MustParse("-100.009", "EUR"), formatter: symbol, expect: "EUR€ -100.01"},
+		{input: money.MustParse("0.123456789", "XOVR"), formatter: iso, expect: "Ð 0.123456789"},
+		{input: money.MustParse("2", "XOVR"), formatter: symbol, expect: "Ð 2"},
+		// Fall back to the currency formatter
+		{input: money.MustParse("1.0", "CHF"), formatter: iso, expect: "CHF 1.00"},
+		{input: money.MustParse("1.0", "USD"), formatter: symbol, expect: "$ 1.00"},
+	}
+
+	for i, test := range table {
+		input := test.formatter.Wrap(test.input)
+
+		p := message.NewPrinter(language.English)
+		res := p.Sprintf("%f", input)
+
+		if test.expect != res {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, res)
+		}
+	}
+}
+
+func TestMoney_FormatParts(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input *money.Money
+		lang  language.Tag
+		major string
+		minor string
+	}{
+		{input: money.MustParse("120.50", "USD"), lang: language.English, major: "120", minor: "50"},
+		{input: money.MustParse("120.5", "USD"), lang: language.English, major: "120", minor: "50"},
+		{input: money.MustParse("120.505", "USD"), lang: language.English, major: "120", minor: "51"},
+		{input: money.MustParse("0.05", "USD"), lang: language.English, major: "0", minor: "05"},
+		{input: money.MustParse("-0.05", "USD"), lang: language.English, major: "-0", minor: "05"},
+		{input: money.MustParse("1234.50", "USD"), lang: language.English, major: "1,234", minor: "50"},
+		{input: money.MustParse("1234.50", "EUR"), lang: language.German, major: "1.234", minor: "50"},
+		{input: money.MustParse("120", "JPY"), lang: language.English, major: "120", minor: ""},
+		{input: money.MustParse("-120.4", "JPY"), lang: language.English, major: "-120", minor: ""},
+		{input: money.MustParse("1.2345", "BHD"), lang: language.English, major: "1", minor: "235"},
+		{input: money.MustParse("123456789012345678901234.50", "USD"), lang: language.English, major: "123,456,789,012,345,678,901,234", minor: "50"},
+		{input: money.MustParse("-9223372036854775808.50", "EUR"), lang: language.German, major: "-9.223.372.036.854.775.808", minor: "50"},
+	}
+
+	for i, test := range table {
+		major, minor := test.input.FormatParts(test.lang)
+		if test.major != major || test.minor != minor {
+			t.Errorf("#%d - expect %q %q, but got %q %q", i, test.major, test.minor, major, minor)
+		}
+	}
+}
+
+func TestMoney_AmountString(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  *money.Money
+		expect string
+	}{
+		{input: money.MustParse("120.5", "USD"), expect: "120.50"},
+		{input: money.MustParse("120.505", "USD"), expect: "120.51"},
+		{input: money.MustParse("1234567.8", "USD"), expect: "1234567.80"},
+		{input: money.MustParse("-0.001", "USD"), expect: "0.00"},
+		{input: money.MustParse("120", "JPY"), expect: "120"},
+		{input: money.MustParse("120.5", "JPY"), expect: "121"},
+		{input: money.MustParse("-120.4", "JPY"), expect: "-120"},
+		{input: money.MustParse("1.2345", "BHD"), expect: "1.235"},
+		{input: money.MustParse("1", "BHD"), expect: "1.000"},
+		{input: money.MustParse("1.23456", "CLF"), expect: "1.2346"},
+	}
+
+	for i, test := range table {
+		if res := test.input.AmountString(); test.expect != res {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, res)
+		}
+	}
+}
+
+func TestParseWithSymbol(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  string
+		lang   language.Tag
+		expect *money.Money
+		err    error
+	}{
+		{input: "$120.50", lang: language.AmericanEnglish, expect: money.MustParse("120.50", "USD")},
+		{input: "$ 1,120.50", lang: language.AmericanEnglish, expect: money.MustParse("1120.50", "USD")},
+		{input: "-$120.50", lang: language.AmericanEnglish, expect: money.MustParse("-120.50", "USD")},
+		{input: "$120.50", lang: language.MustParse("en-CA"), expect: money.MustParse("120.50", "CAD")},
+		{input: "€1.000,00", lang: language.German, expect: money.MustParse("1000.00", "EUR")},
+		{input: "1.000,00 €", lang: language.German, expect: money.MustParse("1000.00", "EUR")},
+		{input: "€1,00", lang: language.French, expect: money.MustParse("1.00", "EUR")},
+		{input: "1 000,50 €", lang: language.French, expect: money.MustParse("1000.50", "EUR")},
+		{input: "CHF 1’000.50", lang: language.MustParse("de-CH"), expect: money.MustParse("1000.50", "CHF")},
+		{input: "US$120", lang: language.Und, expect: money.MustParse("120", "USD")},
+		{input: "£5", lang: language.Und, expect: money.MustParse("5", "GBP")},
+		// Ambiguous symbols
+		{input: "$120.50", lang: language.Und, err: money.ErrAmbiguousCurrencySymbol},
+		{input: "$120.50", lang: language.German, err: money.ErrAmbiguousCurrencySymbol},
+		// Invalid input
+		{input: "120.50", lang: language.AmericanEnglish, err: money.ErrInvalidCurrency},
+		{input: "#120.50", lang: language.AmericanEnglish, err: money.ErrInvalidCurrency},
+		{input: "$12a.50", lang: language.AmericanEnglish, err: money.ErrInvalidDecimal},
+	}
+
+	for i, test := range table {
+		res, err := money.ParseWithSymbol(test.input, test.lang)
+		if test.err != nil || err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("#%d - expect error %v, but got %v - %s", i, test.err, err, test.input)
+			}
+			continue
+		}
+		if !test.expect.EqualExact(res) {
+			t.Errorf("#%d - expect %s, but got %s - %s", i, test.expect, res, test.input)
+		}
+	}
+}
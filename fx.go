@@ -0,0 +1,71 @@
+package money
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrInvalidCurrencyPair indicates that the string is not a pair of
+	// distinct currencies separated by a slash, such as EUR/USD
+	ErrInvalidCurrencyPair = errors.New("invalid currency pair")
+	// ErrInvalidExchangeRate indicates that an exchange rate is zero or
+	// negative
+	ErrInvalidExchangeRate = errors.New("invalid exchange rate")
+)
+
+// CurrencyPair is an FX quote of the Base currency in the Quote currency.
+//
+// Examples:
+//   * EUR/USD - price of 1 euro in US dollars
+//   * USD/JPY - price of 1 dollar in yens
+type CurrencyPair struct {
+	Base  Currency
+	Quote Currency
+}
+
+// ParseCurrencyPair parses a currency pair written as BASE/QUOTE. It returns
+// an error if a leg is not a valid currency, or if both legs are identical.
+//
+//   e.g. EUR/USD
+func ParseCurrencyPair(s string) (CurrencyPair, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return CurrencyPair{}, ErrInvalidCurrencyPair
+	}
+	base, err := ParseCurrency(parts[0])
+	if err != nil {
+		return CurrencyPair{}, err
+	}
+	quote, err := ParseCurrency(parts[1])
+	if err != nil {
+		return CurrencyPair{}, err
+	}
+	if base == quote {
+		return CurrencyPair{}, ErrInvalidCurrencyPair
+	}
+	return CurrencyPair{Base: base, Quote: quote}, nil
+}
+
+// String returns the BASE/QUOTE representation of the pair (e.g. EUR/USD)
+func (p CurrencyPair) String() string {
+	return p.Base.String() + "/" + p.Quote.String()
+}
+
+// ApplyRate converts m from the base to the quote currency of pair at the given
+// rate, and rounds the result to the quote currency scale. It returns
+// ErrCurrencyMismatch when m is not in the base currency.
+//
+//   e.g. 100.00 EUR EUR/USD at 1.0825	-> 108.25 USD
+func ApplyRate(m *Money, pair CurrencyPair, rate Decimal) (*Money, error) {
+	if m.Currency != pair.Base {
+		return nil, ErrCurrencyMismatch
+	}
+	if rate.Sign() != SignPositive {
+		return nil, ErrInvalidExchangeRate
+	}
+	return &Money{
+		Amount:   pair.Quote.round(m.Amount.Mul(rate)),
+		Currency: pair.Quote,
+	}, nil
+}
@@ -0,0 +1,78 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/deixis/money"
+)
+
+func TestParseCurrencyPair(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  string
+		expect string
+		err    error
+	}{
+		{input: "EUR/USD", expect: "EUR/USD"},
+		{input: "usd/jpy", expect: "USD/JPY"},
+		{input: " CHF / EUR ", expect: "CHF/EUR"},
+		{input: "EUR/XYZ", err: money.ErrInvalidCurrency},
+		{input: "/USD", err: money.ErrInvalidCurrency},
+		{input: "EUR/EUR", err: money.ErrInvalidCurrencyPair},
+		{input: "EURUSD", err: money.ErrInvalidCurrencyPair},
+		{input: "EUR/USD/CHF", err: money.ErrInvalidCurrencyPair},
+		{input: "", err: money.ErrInvalidCurrencyPair},
+	}
+
+	for i, test := range table {
+		res, err := money.ParseCurrencyPair(test.input)
+		if test.err != err {
+			t.Errorf("#%d - expect error %s, but got %s", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if test.expect != res.String() {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, res)
+		}
+	}
+}
+
+func TestApplyRate(t *testing.T) {
+	t.Parallel()
+
+	eurusd := money.CurrencyPair{Base: "EUR", Quote: "USD"}
+	usdjpy := money.CurrencyPair{Base: "USD", Quote: "JPY"}
+
+	table := []struct {
+		input  *money.Money
+		pair   money.CurrencyPair
+		rate   string
+		expect *money.Money
+		err    error
+	}{
+		{input: money.MustParse("100.00", "EUR"), pair: eurusd, rate: "1.0825", expect: money.MustParse("108.25", "USD")},
+		{input: money.MustParse("19.99", "EUR"), pair: eurusd, rate: "1.0825", expect: money.MustParse("21.64", "USD")},
+		{input: money.MustParse("-19.99", "EUR"), pair: eurusd, rate: "1.0825", expect: money.MustParse("-21.64", "USD")},
+		{input: money.MustParse("19.99", "USD"), pair: usdjpy, rate: "151.374", expect: money.MustParse("3026", "JPY")},
+		{input: money.MustParse("100.00", "USD"), pair: eurusd, rate: "1.0825", err: money.ErrCurrencyMismatch},
+		{input: money.MustParse("100.00", "EUR"), pair: eurusd, rate: "0", err: money.ErrInvalidExchangeRate},
+		{input: money.MustParse("100.00", "EUR"), pair: eurusd, rate: "-1.0825", err: money.ErrInvalidExchangeRate},
+	}
+
+	for i, test := range table {
+		res, err := money.ApplyRate(test.input, test.pair, money.MustParseDecimal(test.rate))
+		if test.err != err {
+			t.Errorf("#%d - expect error %s, but got %s", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !test.expect.Equal(res) {
+			t.Errorf("#%d - expect %s %s, but got %s %s", i, test.expect.Amount, test.expect.Currency, res.Amount, res.Currency)
+		}
+	}
+}
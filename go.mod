@@ -1,5 +1,5 @@
 module github.com/deixis/money
 
-go 1.13
+go 1.18
 
 require golang.org/x/text v0.3.2
@@ -0,0 +1,23 @@
+package money
+
+import "encoding/gob"
+
+// RegisterGob registers the package types with encoding/gob, so they can be
+// sent as interface values, such as in a []interface{}. Concrete types, like a
+// *Money field, do not need to be registered.
+//
+// It registers *Money, *GoogleMoney, Decimal and Currency. gob does not tell
+// values from pointers, so a Money sent in an interface is received as a
+// *Money, and a *Decimal as a Decimal.
+//
+// It is safe to call several times.
+func RegisterGob() {
+	for _, v := range []interface{}{
+		&Money{},
+		&GoogleMoney{},
+		Decimal{},
+		Currency(""),
+	} {
+		gob.Register(v)
+	}
+}
@@ -0,0 +1,98 @@
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// nanosScale is the scale of the google.type.Money nanos field
+const nanosScale = 9
+
+// GoogleMoney is a Money encoded in JSON with the shape of the google.type.Money
+// protobuf message, as produced by gRPC-gateway.
+//
+//   e.g. {"currencyCode":"USD","units":"120","nanos":500000000}	-> 120.50 USD
+//
+// Units is an int64, which the proto JSON mapping writes as a string. Bare
+// numbers are accepted as well.
+type GoogleMoney Money
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// The amount is set to the currency scale. Nanos beyond the currency scale are
+// not rounded, they return an error wrapping ErrNonIntegral, since they cannot
+// be represented in that currency. Nanos must be within ±999,999,999 and have
+// the same sign as units, otherwise it returns an error wrapping
+// ErrInvalidDecimal.
+func (x *GoogleMoney) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		CurrencyCode string          `json:"currencyCode"`
+		Units        json.RawMessage `json:"units"`
+		Nanos        int32           `json:"nanos"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	c, err := ParseCurrency(raw.CurrencyCode)
+	if err != nil {
+		return err
+	}
+
+	var units int64
+	if s := bytes.Trim(raw.Units, `"`); len(s) > 0 && !isJSONEmpty(raw.Units) {
+		units, err = strconv.ParseInt(string(s), 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: units %s", ErrInvalidDecimal, raw.Units)
+		}
+	}
+	if raw.Nanos <= -1e9 || raw.Nanos >= 1e9 ||
+		(units > 0 && raw.Nanos < 0) || (units < 0 && raw.Nanos > 0) {
+		return fmt.Errorf("%w: units %d nanos %d", ErrInvalidDecimal, units, raw.Nanos)
+	}
+
+	scale := int32(c.Scale())
+	amount := buildDecimal(units, 0).Add(buildDecimal(int64(raw.Nanos), -nanosScale))
+	if !amount.FitsScale(scale) {
+		return fmt.Errorf("%w: %s %s", ErrNonIntegral, amount, c)
+	}
+
+	if err := checkMagnitude(amount); err != nil {
+		return err
+	}
+
+	x.Amount = amount.rescale(-scale)
+	x.Currency = c
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. It returns an error
+// wrapping ErrNonIntegral when the amount has more than 9 decimals, and
+// ErrOverflow when units do not fit in an int64.
+func (x GoogleMoney) MarshalJSON() ([]byte, error) {
+	// The exponent is checked on the normalised amount before rescaling, which
+	// would otherwise allocate as many digits as the exponent
+	amount := x.Amount.normalize()
+	if amount.exp < -nanosScale {
+		return nil, fmt.Errorf("%w: %d decimals %s", ErrNonIntegral, -amount.exp, x.Currency)
+	}
+	if m, _ := amount.magnitude(); amount.value.Sign() != SignNeutral && m > 18 {
+		return nil, ErrOverflow
+	}
+	units := amount.Truncate(0).rescale(0).value
+	if !units.IsInt64() {
+		return nil, ErrOverflow
+	}
+	nanos := amount.Sub(amount.Truncate(0)).rescale(-nanosScale).value
+
+	return json.Marshal(struct {
+		CurrencyCode Currency `json:"currencyCode"`
+		Units        string   `json:"units"`
+		Nanos        int64    `json:"nanos"`
+	}{
+		CurrencyCode: x.Currency,
+		Units:        strconv.FormatInt(units.Int64(), 10),
+		Nanos:        nanos.Int64(),
+	})
+}
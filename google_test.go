@@ -0,0 +1,90 @@
+package money_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deixis/money"
+)
+
+func TestGoogleMoney_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  string
+		expect *money.Money
+		err    error
+	}{
+		{input: `{"currencyCode":"USD","units":"120","nanos":500000000}`, expect: money.MustParse("120.50", "USD")},
+		{input: `{"currencyCode":"USD","units":120,"nanos":500000000}`, expect: money.MustParse("120.50", "USD")},
+		{input: `{"currencyCode":"USD","units":"-1","nanos":-750000000}`, expect: money.MustParse("-1.75", "USD")},
+		{input: `{"currencyCode":"USD","nanos":-10000000}`, expect: money.MustParse("-0.01", "USD")},
+		{input: `{"currencyCode":"USD","units":"5"}`, expect: money.MustParse("5.00", "USD")},
+		{input: `{"currencyCode":"JPY","units":"120"}`, expect: money.MustParse("120", "JPY")},
+		{input: `{"currencyCode":"BHD","units":"1","nanos":235000000}`, expect: money.MustParse("1.235", "BHD")},
+		// Nanos beyond the currency scale
+		{input: `{"currencyCode":"USD","units":"120","nanos":505000000}`, err: money.ErrNonIntegral},
+		{input: `{"currencyCode":"JPY","units":"120","nanos":500000000}`, err: money.ErrNonIntegral},
+		// Invalid messages
+		{input: `{"currencyCode":"USD","units":"1","nanos":-500000000}`, err: money.ErrInvalidDecimal},
+		{input: `{"currencyCode":"USD","units":"1","nanos":1000000000}`, err: money.ErrInvalidDecimal},
+		{input: `{"currencyCode":"USD","units":"1.5"}`, err: money.ErrInvalidDecimal},
+		{input: `{"currencyCode":"ABC","units":"1"}`, err: money.ErrInvalidCurrency},
+		{input: `{"units":"1"}`, err: money.ErrInvalidCurrency},
+	}
+
+	for i, test := range table {
+		var res money.GoogleMoney
+		err := json.Unmarshal([]byte(test.input), &res)
+		if test.err != nil || err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("#%d - expect error %v, but got %v - %s", i, test.err, err, test.input)
+			}
+			continue
+		}
+		if m := money.Money(res); !test.expect.EqualExact(&m) {
+			t.Errorf("#%d - expect %s, but got %s - %s", i, test.expect, &m, test.input)
+		}
+	}
+}
+
+func TestGoogleMoney_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  *money.Money
+		expect string
+		err    error
+	}{
+		{input: money.MustParse("120.50", "USD"), expect: `{"currencyCode":"USD","units":"120","nanos":500000000}`},
+		{input: money.MustParse("-1.75", "USD"), expect: `{"currencyCode":"USD","units":"-1","nanos":-750000000}`},
+		{input: money.MustParse("-0.01", "USD"), expect: `{"currencyCode":"USD","units":"0","nanos":-10000000}`},
+		{input: money.MustParse("120", "JPY"), expect: `{"currencyCode":"JPY","units":"120","nanos":0}`},
+		{input: money.MustParse("0.0000000001", "USD"), err: money.ErrNonIntegral},
+		{input: money.NewMoney(money.MustParseDecimal("1").MulPow10(100000000), "USD"), err: money.ErrOverflow},
+		{input: money.NewMoney(money.MustParseDecimal("1").MulPow10(-100000000), "USD"), err: money.ErrNonIntegral},
+		{input: money.MustParse("9223372036854775808", "USD"), err: money.ErrOverflow},
+	}
+
+	for i, test := range table {
+		data, err := json.Marshal((*money.GoogleMoney)(test.input))
+		if test.err != nil || err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("#%d - expect error %v, but got %v", i, test.err, err)
+			}
+			continue
+		}
+		if test.expect != string(data) {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, data)
+		}
+
+		var res money.GoogleMoney
+		if err := json.Unmarshal(data, &res); err != nil {
+			t.Fatal(err)
+		}
+		if m := money.Money(res); !test.input.Equal(&m) {
+			t.Errorf("#%d - expect %s, but got %s", i, test.input, &m)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package money
+
+import "errors"
+
+var (
+	// ErrNegativePeriods indicates that a number of periods is negative
+	ErrNegativePeriods = errors.New("negative number of periods")
+	// ErrInvalidRate indicates that a rate would make the growth factor
+	// (1 + rate) zero or negative
+	ErrInvalidRate = errors.New("invalid rate")
+)
+
+// CompoundInterest returns principal * (1 + rate)^periods rounded to the
+// currency scale. The rate is a fraction per period (e.g. 0.01 for 1%).
+//
+//   e.g. 1000.00 CHF at 0.05 over 3 periods	-> 1157.63 CHF
+func CompoundInterest(principal *Money, rate Decimal, periods int64) (*Money, error) {
+	if periods < 0 {
+		return nil, ErrNegativePeriods
+	}
+
+	factor := one.Add(rate).PowInt(periods)
+	return &Money{
+		Amount:   principal.Currency.round(principal.Amount.Mul(factor)),
+		Currency: principal.Currency,
+	}, nil
+}
+
+// PresentValue returns future / (1 + rate)^periods rounded to the currency
+// scale. It is the inverse of CompoundInterest.
+//
+//   e.g. 1157.63 CHF at 0.05 over 3 periods	-> 1000.00 CHF
+func PresentValue(future *Money, rate Decimal, periods int64) (*Money, error) {
+	if periods < 0 {
+		return nil, ErrNegativePeriods
+	}
+	base := one.Add(rate)
+	if base.Sign() != SignPositive {
+		return nil, ErrInvalidRate
+	}
+
+	// Rounded once, since Div would first round to DivisionPrecision
+	factor := base.PowInt(periods)
+	return &Money{
+		Amount:   future.Amount.divRound(factor, int32(future.Currency.Scale())),
+		Currency: future.Currency,
+	}, nil
+}
@@ -0,0 +1,181 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/deixis/money"
+)
+
+func TestCompoundInterest(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		principal *money.Money
+		rate      string
+		periods   int64
+		expect    *money.Money
+		err       error
+	}{
+		{
+			principal: money.MustParse("1000.00", "CHF"),
+			rate:      "0.05",
+			periods:   3,
+			// 1000 * 1.05^3 = 1157.625
+			expect: money.MustParse("1157.63", "CHF"),
+		},
+		{
+			principal: money.MustParse("100.00", "USD"),
+			rate:      "0.01",
+			periods:   3,
+			// 100 * 1.01^3 = 103.0301
+			expect: money.MustParse("103.03", "USD"),
+		},
+		{
+			principal: money.MustParse("100.00", "USD"),
+			rate:      "0.01",
+			periods:   0,
+			expect:    money.MustParse("100.00", "USD"),
+		},
+		{
+			principal: money.MustParse("10000", "JPY"),
+			rate:      "-0.1",
+			periods:   2,
+			expect:    money.MustParse("8100", "JPY"),
+		},
+		{
+			principal: money.MustParse("100.00", "USD"),
+			rate:      "0.01",
+			periods:   -1,
+			err:       money.ErrNegativePeriods,
+		},
+	}
+
+	for i, test := range table {
+		res, err := money.CompoundInterest(test.principal, money.MustParseDecimal(test.rate), test.periods)
+		if test.err != err {
+			t.Errorf("#%d - expect error %s, but got %s", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !test.expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect.Amount, res.Amount)
+		}
+		if test.expect.Amount.String() != res.Amount.String() {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect.Amount, res.Amount)
+		}
+	}
+}
+
+func TestPresentValue(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		future  *money.Money
+		rate    string
+		periods int64
+		expect  *money.Money
+		err     error
+	}{
+		{
+			future:  money.MustParse("1157.63", "CHF"),
+			rate:    "0.05",
+			periods: 3,
+			expect:  money.MustParse("1000.00", "CHF"),
+		},
+		{
+			future:  money.MustParse("100.00", "USD"),
+			rate:    "0",
+			periods: 12,
+			expect:  money.MustParse("100.00", "USD"),
+		},
+		{
+			future:  money.MustParse("100.00", "USD"),
+			rate:    "0.1",
+			periods: 1,
+			expect:  money.MustParse("90.91", "USD"),
+		},
+		{
+			// Exactly 0.00499999999999999999, which rounds to 0.005 first at
+			// DivisionPrecision
+			future:  money.MustParse("0.00999999999999999998", "USD"),
+			rate:    "1",
+			periods: 1,
+			expect:  money.MustParse("0.00", "USD"),
+		},
+		{
+			future:  money.MustParse("-100.00", "USD"),
+			rate:    "0.1",
+			periods: 1,
+			expect:  money.MustParse("-90.91", "USD"),
+		},
+		{
+			future:  money.MustParse("100.00", "USD"),
+			rate:    "0.1",
+			periods: -1,
+			err:     money.ErrNegativePeriods,
+		},
+		{
+			future:  money.MustParse("100.00", "USD"),
+			rate:    "-1",
+			periods: 1,
+			err:     money.ErrInvalidRate,
+		},
+		{
+			future:  money.MustParse("100.00", "USD"),
+			rate:    "-1.5",
+			periods: 1,
+			err:     money.ErrInvalidRate,
+		},
+	}
+
+	for i, test := range table {
+		res, err := money.PresentValue(test.future, money.MustParseDecimal(test.rate), test.periods)
+		if test.err != err {
+			t.Errorf("#%d - expect error %s, but got %s", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !test.expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect.Amount, res.Amount)
+		}
+	}
+}
+
+func TestPresentValue_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		principal *money.Money
+		rate      string
+		periods   int64
+	}{
+		{principal: money.MustParse("1000.00", "CHF"), rate: "0.05", periods: 3},
+		{principal: money.MustParse("1234.56", "USD"), rate: "0.0125", periods: 24},
+		{principal: money.MustParse("99.99", "EUR"), rate: "0.2", periods: 10},
+		{principal: money.MustParse("50000", "JPY"), rate: "0.03", periods: 5},
+	}
+
+	for i, test := range table {
+		rate := money.MustParseDecimal(test.rate)
+		future, err := money.CompoundInterest(test.principal, rate, test.periods)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := money.PresentValue(future, rate, test.periods)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Rounding the future value to the currency scale can move the present
+		// value by at most one unit
+		tolerance := test.principal.Currency.RoundUnit(money.RoundingStandard)
+		diff := res.Amount.Sub(test.principal.Amount).Abs()
+		if diff.Cmp(tolerance) > 0 {
+			t.Errorf("#%d - expect %s, but got %s", i, test.principal.Amount, res.Amount)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package money
+
+// Invoice aggregates invoice lines in a single currency and computes the
+// subtotal, tax and total.
+//
+// Each line amount is rounded to the currency scale, as is the tax amount, so
+// Subtotal() + Tax() always equals Total().
+type Invoice struct {
+	currency Currency
+	lines    []InvoiceLine
+	taxRate  Decimal
+}
+
+// InvoiceLine is a line item of an Invoice
+type InvoiceLine struct {
+	Description string
+	UnitPrice   *Money
+	Quantity    int64
+	// Amount is UnitPrice * Quantity rounded to the currency scale
+	Amount *Money
+}
+
+// NewInvoice returns an empty invoice in the given currency
+func NewInvoice(c Currency) *Invoice {
+	return &Invoice{
+		currency: c,
+		taxRate:  zero,
+	}
+}
+
+// AddLine adds a line of qty units at the given unit price. It returns
+// ErrCurrencyMismatch if the unit price is not in the invoice currency.
+func (inv *Invoice) AddLine(description string, unit *Money, qty int64) error {
+	if unit.Currency != inv.currency {
+		return ErrCurrencyMismatch
+	}
+	inv.lines = append(inv.lines, InvoiceLine{
+		Description: description,
+		UnitPrice:   unit,
+		Quantity:    qty,
+		Amount: &Money{
+			Amount:   inv.currency.round(unit.Amount.Mul(buildDecimal(qty, 0))),
+			Currency: inv.currency,
+		},
+	})
+	return nil
+}
+
+// Lines returns the invoice lines
+func (inv *Invoice) Lines() []InvoiceLine {
+	return inv.lines
+}
+
+// ApplyTax sets the tax rate applied on the subtotal as a fraction (e.g. 0.077
+// for 7.7%)
+func (inv *Invoice) ApplyTax(rate Decimal) {
+	inv.taxRate = rate
+}
+
+// Subtotal returns the sum of all line amounts
+func (inv *Invoice) Subtotal() *Money {
+	sum := zero.rescale(-int32(inv.currency.Scale()))
+	for _, l := range inv.lines {
+		sum = sum.Add(l.Amount.Amount)
+	}
+	return &Money{
+		Amount:   sum,
+		Currency: inv.currency,
+	}
+}
+
+// Tax returns the tax amount on the subtotal rounded to the currency scale
+func (inv *Invoice) Tax() *Money {
+	return &Money{
+		Amount:   inv.currency.round(inv.Subtotal().Amount.Mul(inv.taxRate)),
+		Currency: inv.currency,
+	}
+}
+
+// Total returns the subtotal plus tax
+func (inv *Invoice) Total() *Money {
+	return &Money{
+		Amount:   inv.Subtotal().Amount.Add(inv.Tax().Amount),
+		Currency: inv.currency,
+	}
+}
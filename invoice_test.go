@@ -0,0 +1,81 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/deixis/money"
+)
+
+func TestInvoice(t *testing.T) {
+	t.Parallel()
+
+	inv := money.NewInvoice("CHF")
+	if err := inv.AddLine("Coffee beans", money.MustParse("19.90", "CHF"), 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := inv.AddLine("Filters", money.MustParse("4.35", "CHF"), 2); err != nil {
+		t.Fatal(err)
+	}
+	inv.ApplyTax(money.MustParseDecimal("0.077"))
+
+	if len(inv.Lines()) != 2 {
+		t.Fatalf("expect 2 lines, but got %d", len(inv.Lines()))
+	}
+
+	table := []struct {
+		name   string
+		res    *money.Money
+		expect *money.Money
+	}{
+		{name: "line 1", res: inv.Lines()[0].Amount, expect: money.MustParse("59.70", "CHF")},
+		{name: "line 2", res: inv.Lines()[1].Amount, expect: money.MustParse("8.70", "CHF")},
+		{name: "subtotal", res: inv.Subtotal(), expect: money.MustParse("68.40", "CHF")},
+		// 68.40 * 0.077 = 5.2668
+		{name: "tax", res: inv.Tax(), expect: money.MustParse("5.27", "CHF")},
+		{name: "total", res: inv.Total(), expect: money.MustParse("73.67", "CHF")},
+	}
+	for _, test := range table {
+		if !test.expect.Equal(test.res) {
+			t.Errorf("%s - expect %s, but got %s", test.name, test.expect.Amount, test.res.Amount)
+		}
+	}
+
+	sum := inv.Subtotal().Amount.Add(inv.Tax().Amount)
+	if !sum.Equal(inv.Total().Amount) {
+		t.Errorf("expect subtotal + tax %s to equal total %s", sum, inv.Total().Amount)
+	}
+}
+
+func TestInvoice_Rounding(t *testing.T) {
+	t.Parallel()
+
+	inv := money.NewInvoice("USD")
+	if err := inv.AddLine("Widget", money.MustParse("0.333", "USD"), 3); err != nil {
+		t.Fatal(err)
+	}
+	inv.ApplyTax(money.MustParseDecimal("0.0825"))
+
+	// 0.999 -> 1.00, 1.00 * 0.0825 = 0.0825 -> 0.08
+	expect := money.MustParse("1.08", "USD")
+	if !expect.Equal(inv.Total()) {
+		t.Errorf("expect %s, but got %s", expect.Amount, inv.Total().Amount)
+	}
+}
+
+func TestInvoice_CurrencyMismatch(t *testing.T) {
+	t.Parallel()
+
+	inv := money.NewInvoice("CHF")
+	err := inv.AddLine("Coffee beans", money.MustParse("19.90", "EUR"), 1)
+	if err != money.ErrCurrencyMismatch {
+		t.Errorf("expect error %s, but got %s", money.ErrCurrencyMismatch, err)
+	}
+	if len(inv.Lines()) != 0 {
+		t.Errorf("expect no line, but got %d", len(inv.Lines()))
+	}
+
+	expect := money.MustParse("0.00", "CHF")
+	if !expect.Equal(inv.Total()) {
+		t.Errorf("expect %s, but got %s", expect.Amount, inv.Total().Amount)
+	}
+}
@@ -1,5 +1,34 @@
 package money
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// divisionBuffer is the number of digits kept beyond the currency scale by
+// Money.Div
+const divisionBuffer = 4
+
+var (
+	// ErrCurrencyMismatch indicates that an operation was given amounts in
+	// different currencies
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	// ErrInvalidRange indicates that a lower bound is greater than its upper
+	// bound
+	ErrInvalidRange = errors.New("invalid range")
+	// ErrNonIntegral indicates that an amount has digits beyond the requested
+	// scale
+	ErrNonIntegral = errors.New("non-integral amount")
+	// ErrMagnitudeExceeded indicates that an amount is larger than
+	// MaxMoneyMagnitude
+	ErrMagnitudeExceeded = errors.New("amount exceeds maximum magnitude")
+)
+
 // Money represents an amount of money for a currency
 //
 // Money is any item or verifiable record that is generally accepted as payment
@@ -9,6 +38,25 @@ type Money struct {
 	Currency Currency `json:"currency"`
 }
 
+// NewMoney returns a Money for the given amount and currency.
+// The currency is not validated, see NewMoneyValidate.
+func NewMoney(amount Decimal, c Currency) *Money {
+	return &Money{
+		Amount:   amount,
+		Currency: c,
+	}
+}
+
+// NewMoneyValidate is like NewMoney, but returns an error if the Money is not
+// valid.
+func NewMoneyValidate(amount Decimal, c Currency) (*Money, error) {
+	m := NewMoney(amount, c)
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // MustParse is like Parse, but panics if the given amount or currency cannot
 // be parsed. It simplifies safe initialisation of Money values.
 func MustParse(amount, currency string) *Money {
@@ -31,11 +79,17 @@ func MustParse(amount, currency string) *Money {
 // the ISO 4217 format.
 //
 //   e.g. CHF 		-> Swiss franc
+//
+// When MaxMoneyMagnitude is set, larger amounts return an error wrapping
+// ErrMagnitudeExceeded.
 func Parse(amount, currency string) (*Money, error) {
 	a, err := ParseDecimal(amount)
 	if err != nil {
 		return nil, err
 	}
+	if err := checkMagnitude(a); err != nil {
+		return nil, err
+	}
 	c, err := ParseCurrency(currency)
 	if err != nil {
 		return nil, err
@@ -46,6 +100,56 @@ func Parse(amount, currency string) (*Money, error) {
 	}, nil
 }
 
+// checkMagnitude returns an error wrapping ErrMagnitudeExceeded when a is
+// larger than MaxMoneyMagnitude
+func checkMagnitude(a Decimal) error {
+	if max := GetConfig().MaxMoneyMagnitude; !max.IsZero() && a.Abs().Cmp(max) > 0 {
+		return fmt.Errorf("%w: %s exceeds %s", ErrMagnitudeExceeded, a, max)
+	}
+	return nil
+}
+
+// ParseToken parses s, a single token made of an amount and a currency code
+// that may be written before or after it, with or without a space.
+//
+//   e.g. 120.50CHF	-> 120.50 CHF
+//   e.g. CHF120.50	-> 120.50 CHF
+//   e.g. USD -5.00	-> -5.00 USD
+//
+// It returns ErrInvalidCurrency when s has no currency code, and an error
+// wrapping ErrInvalidDecimal when letters are mixed with the amount, such as
+// 12CH3F or CHF12USD.
+func ParseToken(s string) (*Money, error) {
+	s = strings.TrimSpace(s)
+	amount := strings.TrimLeftFunc(s, unicode.IsLetter)
+	code := s[:len(s)-len(amount)]
+	if code == "" {
+		amount = strings.TrimRightFunc(s, unicode.IsLetter)
+		code = s[len(amount):]
+	}
+	if code == "" {
+		return nil, ErrInvalidCurrency
+	}
+	if strings.IndexFunc(amount, unicode.IsLetter) >= 0 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+	}
+	return Parse(strings.TrimSpace(amount), code)
+}
+
+// SortMoney sorts ms in place in ascending order. It returns
+// ErrCurrencyMismatch without sorting when ms contains more than one currency.
+func SortMoney(ms []*Money) error {
+	for _, m := range ms {
+		if m.Currency != ms[0].Currency {
+			return ErrCurrencyMismatch
+		}
+	}
+	sort.Slice(ms, func(i, j int) bool {
+		return ms[i].Amount.Cmp(ms[j].Amount) < 0
+	})
+	return nil
+}
+
 // Equal tests whether y equal x. When the currency is different, it will
 // always return false. Currency conversion is currently not supported.
 func (x *Money) Equal(y *Money) bool {
@@ -55,6 +159,52 @@ func (x *Money) Equal(y *Money) bool {
 	return x.Amount.Equal(y.Amount)
 }
 
+// EqualExact is like Equal, but also requires x and y to have the same
+// precision, i.e. the same coefficient and exponent.
+//
+//   e.g. 120.00 CHF == 120.0000 CHF	-> false
+func (x *Money) EqualExact(y *Money) bool {
+	if x.Currency != y.Currency {
+		return false
+	}
+	return x.Amount.exp == y.Amount.exp && x.Amount.value.Cmp(&y.Amount.value) == 0
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// The amount can be either a string or a bare number. Both keep their textual
+// precision, and exponent notation (e.g. 1.205e2) is applied exactly, so the
+// amount is never rounded. Like Parse, it enforces MaxMoneyMagnitude.
+func (x *Money) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Amount   json.RawMessage `json:"amount"`
+		Currency Currency        `json:"currency"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var amount Decimal
+	if err := amount.UnmarshalJSON(raw.Amount); err != nil {
+		return err
+	}
+	if err := checkMagnitude(amount); err != nil {
+		return err
+	}
+	x.Amount = amount
+	x.Currency = raw.Currency
+	return nil
+}
+
+// Key returns a canonical representation of x, which is identical for all
+// amounts considered Equal.
+//
+//   e.g. 120.00 CHF	-> "120.0 CHF"
+//   e.g. 120.0000 CHF	-> "120.0 CHF"
+func (x *Money) Key() string {
+	return x.Amount.normalize().String() + " " + x.Currency.String()
+}
+
 // Validate tests that both the decimal and the currency are valid
 func (x *Money) Validate() error {
 	if err := x.Currency.Validate(); err != nil {
@@ -63,22 +213,260 @@ func (x *Money) Validate() error {
 	return x.Amount.Validate()
 }
 
-// Add returns an amount set to the rounded sum x+y.
-// The precision is set to the larger of x's or y's precision before the
-// operation.
-// Rounding is performed according to the default rounding mode
-func Add(x, y *Money) *Money {
-	z := Money{}
-	return &z
+// GoString implements the fmt.GoStringer interface, so %#v prints x as a
+// copy-pasteable MustParse call, such as money.MustParse("120.50", "CHF").
+func (x *Money) GoString() string {
+	return fmt.Sprintf("money.MustParse(%q, %q)", x.Amount.String(), x.Currency.String())
 }
 
-// Sub returns an amount set to the rounded difference x-y.
-// Precision, rounding, and accuracy reporting are as for Add.
-// Sub panics with ErrNaN if x and y are infinities with equal
-// signs.
-func Sub(x, y *Money) *Money {
-	z := Money{}
-	return &z
+// TruncateToCurrency returns x truncated toward zero to the currency standard
+// scale. Unlike rounding, it never increases the absolute amount.
+//
+//   e.g. 120.999 USD	-> 120.99 USD
+func (x *Money) TruncateToCurrency() *Money {
+	return &Money{
+		Amount:   x.Amount.Truncate(int32(x.Currency.Scale())),
+		Currency: x.Currency,
+	}
+}
+
+// CapScale returns x truncated toward zero to at most the currency standard
+// scale plus extra digits, so chains of operations do not accumulate precision.
+// Amounts with fewer digits are unchanged. A negative extra removes digits
+// from the currency scale, down to whole units: the cap is never below zero
+// decimals.
+//
+//   e.g. 3.333333 USD 2	-> 3.3333 USD
+//   e.g. 3.3 USD 2	-> 3.3 USD
+//   e.g. 1234.5 JPY -2	-> 1234 JPY
+func (x *Money) CapScale(extra int32) *Money {
+	scale := int32(x.Currency.Scale()) + extra
+	if scale < 0 {
+		scale = 0
+	}
+	return &Money{
+		Amount:   x.Amount.Truncate(scale),
+		Currency: x.Currency,
+	}
+}
+
+// IsCashRoundable reports whether the amount is already a whole multiple of
+// the currency cash rounding unit, i.e. cash rounding would not change it.
+//
+//   e.g. 120.05 CHF	-> true
+//   e.g. 120.03 CHF	-> false
+func (x *Money) IsCashRoundable() bool {
+	return x.Amount.IsDivisibleBy(x.Currency.RoundUnit(RoundingCash))
+}
+
+// AddToScale returns x+y rounded to the currency standard scale.
+//
+// Unlike Decimal.Add, which keeps the larger precision of both operands, the
+// result precision never grows beyond the currency scale, even after many
+// additions.
+//
+//   e.g. 120.00 USD + 0.005 USD	-> 120.01 USD
+func (x *Money) AddToScale(y *Money) (*Money, error) {
+	if x.Currency != y.Currency {
+		return nil, ErrCurrencyMismatch
+	}
+	return &Money{
+		Amount:   x.Currency.round(x.Amount.Add(y.Amount)),
+		Currency: x.Currency,
+	}, nil
+}
+
+// ClampToScale returns x clamped to [min, max] and rounded to the currency
+// standard scale. It returns ErrCurrencyMismatch when the currencies differ and
+// ErrInvalidRange when min is greater than max.
+//
+//   e.g. 4.999 USD [5.00, 10.00]		-> 5.00 USD
+//   e.g. 7.456 USD [5.00, 10.00]		-> 7.46 USD
+//   e.g. 12.00 USD [5.00, 10.00]		-> 10.00 USD
+func (x *Money) ClampToScale(min, max *Money) (*Money, error) {
+	if x.Currency != min.Currency || x.Currency != max.Currency {
+		return nil, ErrCurrencyMismatch
+	}
+	if min.Amount.Cmp(max.Amount) > 0 {
+		return nil, ErrInvalidRange
+	}
+	amount := MaxDecimal(min.Amount, MinDecimal(max.Amount, x.Amount))
+	return &Money{
+		Amount:   x.Currency.round(amount),
+		Currency: x.Currency,
+	}, nil
+}
+
+// ChangeFrom returns the change due when x is paid with tendered, which is
+// tendered - x. It returns ErrCurrencyMismatch when the currencies differ and
+// ErrInsufficientFunds when tendered is less than x.
+//
+// The change is not rounded, so callers paying in cash should round it to the
+// currency cash unit.
+//
+//   e.g. 7.45 CHF from 10.00 CHF	-> 2.55 CHF
+//   e.g. 7.45 CHF from 7.45 CHF	-> 0.00 CHF
+func (x *Money) ChangeFrom(tendered *Money) (*Money, error) {
+	if x.Currency != tendered.Currency {
+		return nil, ErrCurrencyMismatch
+	}
+	if tendered.Amount.Cmp(x.Amount) < 0 {
+		return nil, ErrInsufficientFunds
+	}
+	return &Money{
+		Amount:   tendered.Amount.Sub(x.Amount),
+		Currency: x.Currency,
+	}, nil
+}
+
+// MinWith returns the smaller of x and y, or x when they are equal, regardless
+// of their precision. It returns ErrCurrencyMismatch when the currencies
+// differ.
+//
+//   e.g. 12.50 USD, 9.99 USD	-> 9.99 USD
+func (x *Money) MinWith(y *Money) (*Money, error) {
+	if x.Currency != y.Currency {
+		return nil, ErrCurrencyMismatch
+	}
+	if y.Amount.Cmp(x.Amount) < 0 {
+		return y, nil
+	}
+	return x, nil
+}
+
+// MaxWith returns the larger of x and y, or x when they are equal, regardless
+// of their precision. It returns ErrCurrencyMismatch when the currencies
+// differ.
+//
+//   e.g. 12.50 USD, 9.99 USD	-> 12.50 USD
+func (x *Money) MaxWith(y *Money) (*Money, error) {
+	if x.Currency != y.Currency {
+		return nil, ErrCurrencyMismatch
+	}
+	if y.Amount.Cmp(x.Amount) > 0 {
+		return y, nil
+	}
+	return x, nil
+}
+
+// NonNegative returns x, or zero at the currency scale when x is negative,
+// such as to display a balance after fees.
+//
+//   e.g. -1.25 USD	-> 0.00 USD
+//   e.g. 1.25 USD	-> 1.25 USD
+func (x *Money) NonNegative() *Money {
+	amount := x.Amount
+	if amount.Sign() == SignNegative {
+		amount = buildDecimal(0, -int32(x.Currency.Scale()))
+	}
+	return &Money{
+		Amount:   amount,
+		Currency: x.Currency,
+	}
+}
+
+// ScaledAmount returns the amount multiplied by 10^scale as an integer-valued
+// Decimal, such as the number of minor units when scale is the currency scale.
+// It returns ErrNonIntegral when the amount has digits beyond scale.
+//
+//   e.g. 120.50 USD 2		-> 12050
+//   e.g. 120.505 USD 2	-> ErrNonIntegral
+func (x *Money) ScaledAmount(scale int32) (Decimal, error) {
+	if !x.Amount.FitsScale(scale) {
+		return Decimal{}, ErrNonIntegral
+	}
+	exp := int64(x.Amount.exp) + int64(scale)
+	if exp < math.MinInt32 || exp > math.MaxInt32 {
+		return Decimal{}, ErrOverflow
+	}
+	// Multiplying by 10^scale only shifts the exponent
+	scaled := Decimal{value: x.Amount.value, exp: int32(exp)}
+	return scaled.rescale(0), nil
+}
+
+// Sign returns the sign of the amount of x, as one of SignNegative,
+// SignNeutral or SignPositive.
+func (x *Money) Sign() int {
+	return x.Amount.Sign()
+}
+
+// Abs returns the absolute value of x
+func (x *Money) Abs() *Money {
+	return &Money{
+		Amount:   x.Amount.Abs(),
+		Currency: x.Currency,
+	}
+}
+
+// Neg returns -x
+func (x *Money) Neg() *Money {
+	return &Money{
+		Amount:   x.Amount.Neg(),
+		Currency: x.Currency,
+	}
+}
+
+// MulInt returns x*n. The precision of x is kept.
+//
+//   e.g. 12.50 CHF * 3	-> 37.50 CHF
+func (x *Money) MulInt(n int64) *Money {
+	return &Money{
+		Amount:   x.Amount.Mul(buildDecimal(n, 0)),
+		Currency: x.Currency,
+	}
+}
+
+// Div returns x/d rounded to the currency scale plus 4 digits, rather than the
+// global division precision. DivRound overrides the precision.
+//
+//   e.g. 10.00 USD / 3	-> 3.333333 USD
+//
+// Div panics if d is zero.
+func (x *Money) Div(d Decimal) *Money {
+	return x.DivRound(d, int32(x.Currency.Scale())+divisionBuffer)
+}
+
+// DivRound returns x/d rounded to precision digits after the decimal point,
+// like Decimal.Round.
+//
+//   e.g. 10.00 USD / 3 at 2	-> 3.33 USD
+//
+// DivRound panics if d is zero.
+func (x *Money) DivRound(d Decimal, precision int32) *Money {
+	return &Money{
+		Amount:   x.Amount.divRound(d, precision),
+		Currency: x.Currency,
+	}
+}
+
+// Add returns an amount set to the sum x+y. It returns ErrCurrencyMismatch
+// when the currencies differ.
+// The precision is set to the larger of x's or y's precision, and the sum is
+// not rounded.
+//
+//   e.g. 120.50 CHF + 0.125 CHF	-> 120.625 CHF
+func Add(x, y *Money) (*Money, error) {
+	if x.Currency != y.Currency {
+		return nil, ErrCurrencyMismatch
+	}
+	return &Money{
+		Amount:   x.Amount.Add(y.Amount),
+		Currency: x.Currency,
+	}, nil
+}
+
+// Sub returns an amount set to the difference x-y.
+// Precision and currency checks are as for Add.
+//
+//   e.g. 120.50 CHF - 0.125 CHF	-> 120.375 CHF
+func Sub(x, y *Money) (*Money, error) {
+	if x.Currency != y.Currency {
+		return nil, ErrCurrencyMismatch
+	}
+	return &Money{
+		Amount:   x.Amount.Sub(y.Amount),
+		Currency: x.Currency,
+	}, nil
 }
 
 // Mul sets z to the rounded product x*y and returns z.
@@ -1,10 +1,16 @@
 package money_test
 
 import (
+	"bytes"
+	"encoding/gob"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/deixis/money"
+	"golang.org/x/text/language"
 )
 
 func TestMoney_Equal(t *testing.T) {
@@ -40,6 +46,221 @@ func TestMoney_Equal(t *testing.T) {
 	}
 }
 
+func TestMoney_EqualExact(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		x      *money.Money
+		y      *money.Money
+		equal  bool
+		expect bool
+	}{
+		{
+			x: money.MustParse("120.00", "CHF"),
+			y: money.MustParse("120.00", "CHF"), equal: true, expect: true},
+		{
+			x: money.MustParse("120.00", "CHF"),
+			y: money.MustParse("120.0000", "CHF"), equal: true, expect: false},
+		{
+			x: money.MustParse("120.00", "CHF"),
+			y: money.MustParse("-120.00", "CHF"), equal: false, expect: false},
+		{
+			x: money.MustParse("120.00", "CHF"),
+			y: money.MustParse("120.00", "EUR"), equal: false, expect: false},
+		{
+			x: money.MustParse("0.00", "CHF"),
+			y: money.MustParse("0.000", "CHF"), equal: true, expect: false},
+	}
+
+	for i, test := range table {
+		res := test.x.EqualExact(test.y)
+		if test.expect != res {
+			t.Errorf("#%d - expect %t, but got %t", i, test.expect, res)
+		}
+		if equal := test.x.Equal(test.y); test.equal != equal {
+			t.Errorf("#%d - expect Equal %t, but got %t", i, test.equal, equal)
+		}
+	}
+}
+
+func TestNewMoney(t *testing.T) {
+	t.Parallel()
+
+	amount := money.MustParseDecimal("100.00").Div(money.MustParseDecimal("8"))
+	m := money.NewMoney(amount, "CHF")
+	expect := money.MustParse("12.5", "CHF")
+	if !expect.Equal(m) {
+		t.Errorf("expect %s, but got %s", expect.Amount, m.Amount)
+	}
+}
+
+func TestNewMoneyValidate(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		amount   money.Decimal
+		currency money.Currency
+		err      error
+	}{
+		{amount: money.MustParseDecimal("12.5"), currency: "CHF"},
+		{amount: money.MustParseDecimal("-12.5"), currency: "USD"},
+		{amount: money.MustParseDecimal("12.5"), currency: "", err: money.ErrInvalidCurrency},
+		{amount: money.MustParseDecimal("12.5"), currency: "ZZZ", err: money.ErrInvalidCurrency},
+	}
+
+	for i, test := range table {
+		res, err := money.NewMoneyValidate(test.amount, test.currency)
+		if test.err != err {
+			t.Errorf("#%d - expect error %s, but got %s", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if test.currency != res.Currency || !test.amount.Equal(res.Amount) {
+			t.Errorf("#%d - expect %s %s, but got %s %s", i, test.amount, test.currency, res.Amount, res.Currency)
+		}
+	}
+}
+
+func TestParse_MaxMoneyMagnitude(t *testing.T) {
+	// Not parallel, since it changes the package config. Parallel tests only
+	// start once the sequential ones are done.
+	defaults := money.GetConfig()
+	defer money.SetConfig(defaults)
+
+	huge := "1000000000000000.01"
+	if _, err := money.Parse(huge, "USD"); err != nil {
+		t.Fatalf("expect %s to be accepted without a cap, but got %s", huge, err)
+	}
+
+	c := defaults
+	c.MaxMoneyMagnitude = money.MustParseDecimal("1000000000000000") // A quadrillion
+	money.SetConfig(c)
+
+	table := []struct {
+		input string
+		err   error
+	}{
+		{input: "1000000000000000"},
+		{input: "-1000000000000000.00"},
+		{input: "120.50"},
+		{input: huge, err: money.ErrMagnitudeExceeded},
+		{input: "-" + huge, err: money.ErrMagnitudeExceeded},
+		{input: "99999999999999999999", err: money.ErrMagnitudeExceeded},
+	}
+
+	for i, test := range table {
+		_, err := money.Parse(test.input, "USD")
+		if !errors.Is(err, test.err) {
+			t.Errorf("#%d - expect error %v, but got %v - %s", i, test.err, err, test.input)
+		}
+		_, err = money.ParseToken(test.input + "USD")
+		if !errors.Is(err, test.err) {
+			t.Errorf("#%d - expect token error %v, but got %v - %s", i, test.err, err, test.input)
+		}
+		_, err = money.ParseWithSymbol("US$"+test.input, language.AmericanEnglish)
+		if !errors.Is(err, test.err) {
+			t.Errorf("#%d - expect symbol error %v, but got %v - %s", i, test.err, err, test.input)
+		}
+		for _, data := range []string{
+			`{"amount":"` + test.input + `","currency":"USD"}`,
+			`{"amount":` + test.input + `,"currency":"USD"}`,
+		} {
+			err = json.Unmarshal([]byte(data), &money.Money{})
+			if !errors.Is(err, test.err) {
+				t.Errorf("#%d - expect JSON error %v, but got %v - %s", i, test.err, err, data)
+			}
+		}
+	}
+
+	google := []struct {
+		input string
+		err   error
+	}{
+		{input: `{"currencyCode":"USD","units":"1000000000000000","nanos":0}`},
+		{input: `{"currencyCode":"USD","units":"-999999999999999","nanos":-990000000}`},
+		{input: `{"currencyCode":"USD","units":"1000000000000000","nanos":10000000}`, err: money.ErrMagnitudeExceeded},
+		{input: `{"currencyCode":"USD","units":"-1000000000000001","nanos":0}`, err: money.ErrMagnitudeExceeded},
+	}
+	for i, test := range google {
+		err := json.Unmarshal([]byte(test.input), &money.GoogleMoney{})
+		if !errors.Is(err, test.err) {
+			t.Errorf("#%d - expect error %v, but got %v - %s", i, test.err, err, test.input)
+		}
+	}
+}
+
+func TestParseToken(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  string
+		expect *money.Money
+		err    error
+	}{
+		{input: "120.50CHF", expect: money.MustParse("120.50", "CHF")},
+		{input: "CHF120.50", expect: money.MustParse("120.50", "CHF")},
+		{input: "USD -5.00", expect: money.MustParse("-5.00", "USD")},
+		{input: " -5.00 usd ", expect: money.MustParse("-5.00", "USD")},
+		{input: "12CH3F", err: money.ErrInvalidDecimal},
+		{input: "CHF12USD", err: money.ErrInvalidDecimal},
+		{input: "120.50", err: money.ErrInvalidCurrency},
+		{input: "120.50XYZ", err: money.ErrInvalidCurrency},
+		{input: "CHF", err: money.ErrInvalidDecimal},
+	}
+
+	for i, test := range table {
+		res, err := money.ParseToken(test.input)
+		if !errors.Is(err, test.err) {
+			t.Errorf("#%d - expect error %v, but got %v", i, test.err, err)
+		}
+		if err != nil {
+			continue
+		}
+		if !test.expect.EqualExact(res) {
+			t.Errorf("#%d - expect %s %s, but got %s %s", i, test.expect.Amount, test.expect.Currency, res.Amount, res.Currency)
+		}
+	}
+}
+
+func TestSortMoney(t *testing.T) {
+	t.Parallel()
+
+	input := []*money.Money{
+		money.MustParse("120.10", "CHF"),
+		money.MustParse("-5", "CHF"),
+		money.MustParse("120.099", "CHF"),
+		money.MustParse("0.00", "CHF"),
+	}
+	expect := []*money.Money{
+		money.MustParse("-5", "CHF"),
+		money.MustParse("0.00", "CHF"),
+		money.MustParse("120.099", "CHF"),
+		money.MustParse("120.10", "CHF"),
+	}
+
+	if err := money.SortMoney(input); err != nil {
+		t.Fatal(err)
+	}
+	for i, m := range input {
+		if !expect[i].Equal(m) {
+			t.Errorf("#%d - expect %s, but got %s", i, expect[i].Amount, m.Amount)
+		}
+	}
+
+	mixed := []*money.Money{
+		money.MustParse("120.10", "CHF"),
+		money.MustParse("-5", "EUR"),
+	}
+	if err := money.SortMoney(mixed); err != money.ErrCurrencyMismatch {
+		t.Errorf("expect error %s, but got %s", money.ErrCurrencyMismatch, err)
+	}
+	if mixed[0].Currency != "CHF" {
+		t.Errorf("expect mixed slice to be left untouched")
+	}
+}
+
 func TestMoney_Validate(t *testing.T) {
 	t.Parallel()
 
@@ -126,3 +347,795 @@ func TestMoney_UnmarshalJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestMoney_UnmarshalJSON_Number(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		number string
+		text   string
+		expect string
+	}{
+		{
+			number: `{"amount": 120.5, "currency": "USD"}`,
+			text:   `{"amount": "120.5", "currency": "USD"}`,
+			expect: "120.5",
+		},
+		{
+			number: `{"amount": 120.5000, "currency": "USD"}`,
+			text:   `{"amount": "120.5000", "currency": "USD"}`,
+			expect: "120.5000",
+		},
+		{
+			number: `{"amount": -0.001, "currency": "CHF"}`,
+			text:   `{"amount": "-0.001", "currency": "CHF"}`,
+			expect: "-0.001",
+		},
+		{
+			number: `{"amount": 1.20556e2, "currency": "USD"}`,
+			text:   `{"amount": "120.556", "currency": "USD"}`,
+			expect: "120.556",
+		},
+		{
+			number: `{"amount": 12E-1, "currency": "JPY"}`,
+			text:   `{"amount": "1.2", "currency": "JPY"}`,
+			expect: "1.2",
+		},
+		{
+			number: `{"amount": 1.005e0, "currency": "USD"}`,
+			text:   `{"amount": "1.005", "currency": "USD"}`,
+			expect: "1.005",
+		},
+		{
+			number: `{"amount": 1.005, "currency": "USD"}`,
+			text:   `{"amount": "1.005", "currency": "USD"}`,
+			expect: "1.005",
+		},
+	}
+
+	for i, test := range table {
+		number := &money.Money{}
+		if err := json.Unmarshal([]byte(test.number), number); err != nil {
+			t.Fatalf("#%d - %s", i, err)
+		}
+		text := &money.Money{}
+		if err := json.Unmarshal([]byte(test.text), text); err != nil {
+			t.Fatalf("#%d - %s", i, err)
+		}
+
+		if !number.Equal(text) {
+			t.Errorf("#%d - expect %s to equal %s", i, number.Amount, text.Amount)
+		}
+		if !money.MustParseDecimal(test.expect).Equal(number.Amount) {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, number.Amount)
+		}
+	}
+}
+
+func TestMoney_UnmarshalJSON_Invalid(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input string
+	}{
+		{input: `{"amount": true, "currency": "USD"}`},
+		{input: `{"amount": {}, "currency": "USD"}`},
+		{input: `{"amount": ["1.0"], "currency": "USD"}`},
+		{input: `{"amount": "1.0", "currency": 840}`},
+		{input: `{"amount": "1.0", "currency": "XYZ"}`},
+		{input: `{"amount": "1.0.0", "currency": "USD"}`},
+		// Rescaling these exponents would hang the decoder
+		{input: `{"amount":1e100000000,"currency":"USD"}`},
+		{input: `{"amount":1e-100000000,"currency":"USD"}`},
+	}
+
+	for i, test := range table {
+		res := &money.Money{}
+		if err := json.Unmarshal([]byte(test.input), res); err == nil {
+			t.Errorf("#%d - expect an error, but got %s %s", i, res.Amount, res.Currency)
+		}
+	}
+
+	// Empty values are accepted, Validate reports them
+	empty := []string{
+		`{}`,
+		`{"amount": null, "currency": null}`,
+		`{"amount": "", "currency": ""}`,
+	}
+	for i, input := range empty {
+		res := &money.Money{}
+		if err := json.Unmarshal([]byte(input), res); err != nil {
+			t.Errorf("#%d - expect no error, but got %s", i, err)
+		}
+		if err := res.Validate(); err == nil {
+			t.Errorf("#%d - expect an invalid money", i)
+		}
+	}
+}
+
+func FuzzMoneyJSON(f *testing.F) {
+	seeds := []struct {
+		amount   string
+		currency string
+	}{
+		{amount: "120.0", currency: "CHF"},
+		{amount: "120.00", currency: "CHF"},
+		{amount: "120.0000", currency: "CHF"},
+		{amount: "-120.00", currency: "CHF"},
+		{amount: "0.00", currency: "CHF"},
+		{amount: "-120.12", currency: "EUR"},
+		{amount: "120", currency: "JPY"},
+		{amount: "0.00000001", currency: "usd"},
+		{amount: " 1.5", currency: " chf "},
+		{amount: "1.5", currency: ""},
+	}
+	for _, seed := range seeds {
+		f.Add(seed.amount, seed.currency)
+	}
+
+	f.Fuzz(func(t *testing.T, amount, currency string) {
+		m, err := money.Parse(amount, currency)
+		if err != nil {
+			return
+		}
+
+		data, err := json.Marshal(m)
+		if err != nil {
+			t.Fatalf("cannot marshal %#v: %s", m, err)
+		}
+		res := &money.Money{}
+		if err := json.Unmarshal(data, res); err != nil {
+			t.Fatalf("cannot unmarshal %s: %s", data, err)
+		}
+		if !m.Equal(res) {
+			t.Fatalf("expect %#v, but got %#v", m, res)
+		}
+		if err := res.Validate(); err != nil {
+			t.Fatalf("expect %s to be valid, but got %s", data, err)
+		}
+	})
+}
+
+func TestMoney_IsCashRoundable(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  *money.Money
+		expect bool
+	}{
+		{input: money.MustParse("120.0", "CHF"), expect: true},
+		{input: money.MustParse("120.05", "CHF"), expect: true},
+		{input: money.MustParse("120.10", "CHF"), expect: true},
+		{input: money.MustParse("120.03", "CHF"), expect: false},
+		{input: money.MustParse("120.001", "CHF"), expect: false},
+		{input: money.MustParse("-120.05", "CHF"), expect: true},
+		{input: money.MustParse("-120.08", "CHF"), expect: false},
+		{input: money.MustParse("120.03", "EUR"), expect: true},
+		{input: money.MustParse("120.5", "JPY"), expect: false},
+	}
+
+	for i, test := range table {
+		res := test.input.IsCashRoundable()
+		if test.expect != res {
+			t.Errorf("#%d - expect %t, but got %t - %s", i, test.expect, res, test.input.Amount)
+		}
+	}
+}
+
+func TestMoney_AddToScale(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		x      *money.Money
+		y      *money.Money
+		expect *money.Money
+		err    error
+	}{
+		{
+			x:      money.MustParse("120.00", "USD"),
+			y:      money.MustParse("0.005", "USD"),
+			expect: money.MustParse("120.01", "USD"),
+		},
+		{
+			x:      money.MustParse("120.00", "USD"),
+			y:      money.MustParse("-0.004", "USD"),
+			expect: money.MustParse("120.00", "USD"),
+		},
+		{
+			x:      money.MustParse("120", "JPY"),
+			y:      money.MustParse("0.5", "JPY"),
+			expect: money.MustParse("121", "JPY"),
+		},
+		{
+			x:   money.MustParse("120.00", "USD"),
+			y:   money.MustParse("0.005", "CHF"),
+			err: money.ErrCurrencyMismatch,
+		},
+	}
+
+	for i, test := range table {
+		res, err := test.x.AddToScale(test.y)
+		if test.err != err {
+			t.Errorf("#%d - expect error %s, but got %s", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !test.expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect.Amount, res.Amount)
+		}
+	}
+}
+
+func TestMoney_AddToScale_Repeated(t *testing.T) {
+	t.Parallel()
+
+	x := money.MustParse("0.00", "USD")
+	y := money.MustParse("0.005", "USD")
+	for i := 0; i < 100; i++ {
+		var err error
+		x, err = x.AddToScale(y)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if x.Amount.Exponent() != -2 {
+			t.Fatalf("#%d - expect exponent -2, but got %d", i, x.Amount.Exponent())
+		}
+	}
+
+	expect := money.MustParse("1.00", "USD")
+	if !expect.Equal(x) {
+		t.Errorf("expect %s, but got %s", expect.Amount, x.Amount)
+	}
+}
+
+func TestMoney_GoString(t *testing.T) {
+	t.Parallel()
+
+	re := regexp.MustCompile(`^money\.MustParse\("(.*)", "(.*)"\)$`)
+	table := []struct {
+		input  *money.Money
+		expect string
+	}{
+		{input: money.MustParse("120.50", "CHF"), expect: `money.MustParse("120.50", "CHF")`},
+		{input: money.MustParse("-0.00000001", "USD"), expect: `money.MustParse("-0.00000001", "USD")`},
+		{input: money.MustParse("120", "JPY"), expect: `money.MustParse("120.0", "JPY")`},
+	}
+
+	for i, test := range table {
+		res := fmt.Sprintf("%#v", test.input)
+		if test.expect != res {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, res)
+		}
+
+		m := re.FindStringSubmatch(res)
+		if m == nil {
+			t.Fatalf("#%d - cannot parse %s", i, res)
+		}
+		back := money.MustParse(m[1], m[2])
+		if !test.input.Equal(back) || test.input.Amount.String() != back.Amount.String() {
+			t.Errorf("#%d - expect %s to re-parse to %s, but got %s", i, res, test.input.Amount, back.Amount)
+		}
+	}
+}
+
+func TestMoney_TruncateToCurrency(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  *money.Money
+		expect string
+	}{
+		{input: money.MustParse("120.999", "USD"), expect: "120.99"},
+		{input: money.MustParse("-120.999", "USD"), expect: "-120.99"},
+		{input: money.MustParse("120.5", "USD"), expect: "120.5"},
+		{input: money.MustParse("120.99", "JPY"), expect: "120.0"},
+		{input: money.MustParse("-120.99", "JPY"), expect: "-120.0"},
+		{input: money.MustParse("1.23456", "BHD"), expect: "1.234"},
+		{input: money.MustParse("1.2349", "KWD"), expect: "1.234"},
+	}
+
+	for i, test := range table {
+		res := test.input.TruncateToCurrency()
+		if test.expect != res.Amount.String() {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, res.Amount)
+		}
+		if test.input.Currency != res.Currency {
+			t.Errorf("#%d - expect currency %s, but got %s", i, test.input.Currency, res.Currency)
+		}
+	}
+}
+
+func TestMoney_Sign(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input *money.Money
+		sign  int
+		abs   *money.Money
+		neg   *money.Money
+	}{
+		{input: money.MustParse("120.50", "CHF"), sign: money.SignPositive, abs: money.MustParse("120.50", "CHF"), neg: money.MustParse("-120.50", "CHF")},
+		{input: money.MustParse("0.001", "CHF"), sign: money.SignPositive, abs: money.MustParse("0.001", "CHF"), neg: money.MustParse("-0.001", "CHF")},
+		{input: money.MustParse("0", "CHF"), sign: money.SignNeutral, abs: money.MustParse("0", "CHF"), neg: money.MustParse("0", "CHF")},
+		{input: money.MustParse("-0.00", "CHF"), sign: money.SignNeutral, abs: money.MustParse("0.00", "CHF"), neg: money.MustParse("0.00", "CHF")},
+		{input: money.MustParse("-120.50", "USD"), sign: money.SignNegative, abs: money.MustParse("120.50", "USD"), neg: money.MustParse("120.50", "USD")},
+	}
+
+	for i, test := range table {
+		if res := test.input.Sign(); test.sign != res {
+			t.Errorf("#%d - expect sign %d, but got %d", i, test.sign, res)
+		}
+		if res := test.input.Abs(); !test.abs.EqualExact(res) {
+			t.Errorf("#%d - expect abs %s, but got %s", i, test.abs, res)
+		}
+		if res := test.input.Neg(); !test.neg.EqualExact(res) {
+			t.Errorf("#%d - expect neg %s, but got %s", i, test.neg, res)
+		}
+	}
+}
+
+func TestMoney_CapScale(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  *money.Money
+		extra  int32
+		expect string
+	}{
+		{input: money.MustParse("3.333333", "USD"), extra: 2, expect: "3.3333"},
+		{input: money.MustParse("-3.333333", "USD"), extra: 2, expect: "-3.3333"},
+		{input: money.MustParse("3.333333", "USD"), extra: 0, expect: "3.33"},
+		{input: money.MustParse("3.3", "USD"), extra: 2, expect: "3.3"},
+		{input: money.MustParse("3.33339", "JPY"), extra: 1, expect: "3.3"},
+		{input: money.MustParse("1.0000001", "BHD"), extra: 3, expect: "1.000000"},
+		{input: money.MustParse("3.339", "USD"), extra: -1, expect: "3.3"},
+		// The cap is clamped to zero decimals
+		{input: money.MustParse("1234.5", "JPY"), extra: -2, expect: "1234"},
+		{input: money.MustParse("-3.99", "USD"), extra: -5, expect: "-3"},
+		{input: money.MustParse("1234", "JPY"), extra: -2, expect: "1234"},
+	}
+
+	for i, test := range table {
+		res := test.input.CapScale(test.extra)
+		if expect := money.MustParse(test.expect, test.input.Currency.String()); !expect.EqualExact(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, expect, res)
+		}
+	}
+
+	// Repeated divisions stay within the cap
+	x := money.MustParse("100.00", "USD")
+	for n := 0; n < 20; n++ {
+		x = x.DivRound(money.MustParseDecimal("3"), 16).MulInt(2).CapScale(2)
+		if exp := x.Amount.Exponent(); exp < -4 {
+			t.Fatalf("#%d - expect at most 4 decimals, but got %s", n, x)
+		}
+	}
+}
+
+func TestMoney_MulInt(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  *money.Money
+		n      int64
+		expect *money.Money
+	}{
+		{input: money.MustParse("12.50", "CHF"), n: 3, expect: money.MustParse("37.50", "CHF")},
+		{input: money.MustParse("12.50", "CHF"), n: 0, expect: money.MustParse("0", "CHF")},
+		{input: money.MustParse("12.50", "CHF"), n: -2, expect: money.MustParse("-25", "CHF")},
+	}
+
+	for i, test := range table {
+		res := test.input.MulInt(test.n)
+		if !test.expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect.Amount, res.Amount)
+		}
+	}
+}
+
+func TestMoney_ClampToScale(t *testing.T) {
+	t.Parallel()
+
+	min := money.MustParse("5.00", "USD")
+	max := money.MustParse("10.00", "USD")
+
+	table := []struct {
+		input  *money.Money
+		min    *money.Money
+		max    *money.Money
+		expect *money.Money
+		err    error
+	}{
+		{input: money.MustParse("4.999", "USD"), min: min, max: max, expect: money.MustParse("5.00", "USD")},
+		{input: money.MustParse("-1", "USD"), min: min, max: max, expect: money.MustParse("5.00", "USD")},
+		{input: money.MustParse("5", "USD"), min: min, max: max, expect: money.MustParse("5.00", "USD")},
+		{input: money.MustParse("7.456", "USD"), min: min, max: max, expect: money.MustParse("7.46", "USD")},
+		{input: money.MustParse("9.994", "USD"), min: min, max: max, expect: money.MustParse("9.99", "USD")},
+		{input: money.MustParse("10.001", "USD"), min: min, max: max, expect: money.MustParse("10.00", "USD")},
+		{input: money.MustParse("12.00", "USD"), min: min, max: max, expect: money.MustParse("10.00", "USD")},
+		{input: money.MustParse("7", "JPY"), min: money.MustParse("0.5", "JPY"), max: money.MustParse("6.6", "JPY"), expect: money.MustParse("7", "JPY")},
+		{input: money.MustParse("7.00", "EUR"), min: min, max: max, err: money.ErrCurrencyMismatch},
+		{input: money.MustParse("7.00", "USD"), min: max, max: min, err: money.ErrInvalidRange},
+	}
+
+	for i, test := range table {
+		res, err := test.input.ClampToScale(test.min, test.max)
+		if test.err != err {
+			t.Errorf("#%d - expect error %s, but got %s", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !test.expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect.Amount, res.Amount)
+		}
+		if exp := res.Amount.Exponent(); exp != -int32(res.Currency.Scale()) && !res.Amount.IsZero() {
+			t.Errorf("#%d - expect exponent %d, but got %d", i, -res.Currency.Scale(), exp)
+		}
+	}
+}
+
+func TestMoney_Gob_Map(t *testing.T) {
+	t.Parallel()
+
+	input := map[string]*money.Money{
+		"price":    money.MustParse("120.50", "CHF"),
+		"discount": money.MustParse("-10.0000", "CHF"),
+		"fee":      money.MustParse("0.00000001", "EUR"),
+		"total":    money.MustParse("17950000000000.12", "USD"),
+		"yen":      money.MustParse("1200", "JPY"),
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(input); err != nil {
+		t.Fatal("cannot gob encode", err)
+	}
+	res := map[string]*money.Money{}
+	if err := gob.NewDecoder(&buf).Decode(&res); err != nil {
+		t.Fatal("cannot gob decode", err)
+	}
+
+	if len(input) != len(res) {
+		t.Fatalf("expect %d entries, but got %d", len(input), len(res))
+	}
+	for k, expect := range input {
+		got, ok := res[k]
+		if !ok {
+			t.Errorf("%s - expect entry to exist", k)
+			continue
+		}
+		if !expect.EqualExact(got) {
+			t.Errorf("%s - expect %#v, but got %#v", k, expect, got)
+		}
+	}
+}
+
+func TestRegisterGob(t *testing.T) {
+	t.Parallel()
+
+	money.RegisterGob()
+	money.RegisterGob()
+
+	google := money.GoogleMoney(*money.MustParse("1.25", "USD"))
+	input := []interface{}{
+		money.MustParse("120.50", "CHF"),
+		*money.MustParse("-10.0000", "CHF"),
+		money.MustParseDecimal("0.00000001"),
+		money.Currency("JPY"),
+		&google,
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(input); err != nil {
+		t.Fatal("cannot gob encode", err)
+	}
+	var res []interface{}
+	if err := gob.NewDecoder(&buf).Decode(&res); err != nil {
+		t.Fatal("cannot gob decode", err)
+	}
+
+	if len(input) != len(res) {
+		t.Fatalf("expect %d values, but got %d", len(input), len(res))
+	}
+	if m, ok := res[0].(*money.Money); !ok || !m.EqualExact(input[0].(*money.Money)) {
+		t.Errorf("#0 - expect %#v, but got %#v", input[0], res[0])
+	}
+	if m, ok := res[1].(*money.Money); !ok || !m.EqualExact(money.MustParse("-10.0000", "CHF")) {
+		t.Errorf("#1 - expect %#v, but got %#v", input[1], res[1])
+	}
+	if d, ok := res[2].(money.Decimal); !ok || !d.Equal(input[2].(money.Decimal)) {
+		t.Errorf("#2 - expect %v, but got %v", input[2], res[2])
+	}
+	if c, ok := res[3].(money.Currency); !ok || c != input[3] {
+		t.Errorf("#3 - expect %v, but got %v", input[3], res[3])
+	}
+	if g, ok := res[4].(*money.GoogleMoney); !ok || !(*money.Money)(g).EqualExact((*money.Money)(&google)) {
+		t.Errorf("#4 - expect %v, but got %v", input[4], res[4])
+	}
+}
+
+func TestMoney_Gob_Nested(t *testing.T) {
+	t.Parallel()
+
+	type line struct {
+		Label   string
+		Amounts []*money.Money
+	}
+	type invoice struct {
+		Lines []line
+		Total money.Money
+	}
+
+	input := invoice{
+		Lines: []line{
+			{Label: "a", Amounts: []*money.Money{
+				money.MustParse("1.10", "CHF"),
+				money.MustParse("-2.2", "CHF"),
+			}},
+			{Label: "b", Amounts: []*money.Money{}},
+			{Label: "c", Amounts: []*money.Money{
+				money.MustParse("0.000", "USD"),
+			}},
+		},
+		Total: *money.MustParse("-1.10", "CHF"),
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(input); err != nil {
+		t.Fatal("cannot gob encode", err)
+	}
+	var res invoice
+	if err := gob.NewDecoder(&buf).Decode(&res); err != nil {
+		t.Fatal("cannot gob decode", err)
+	}
+
+	if !input.Total.EqualExact(&res.Total) {
+		t.Errorf("expect total %#v, but got %#v", &input.Total, &res.Total)
+	}
+	if len(input.Lines) != len(res.Lines) {
+		t.Fatalf("expect %d lines, but got %d", len(input.Lines), len(res.Lines))
+	}
+	for i, l := range input.Lines {
+		got := res.Lines[i]
+		if l.Label != got.Label || len(l.Amounts) != len(got.Amounts) {
+			t.Errorf("#%d - expect %s with %d amounts, but got %s with %d", i, l.Label, len(l.Amounts), got.Label, len(got.Amounts))
+			continue
+		}
+		for k, expect := range l.Amounts {
+			if !expect.EqualExact(got.Amounts[k]) {
+				t.Errorf("#%d - expect amount %d %#v, but got %#v", i, k, expect, got.Amounts[k])
+			}
+		}
+	}
+}
+
+func TestAdd_Sub(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		x   *money.Money
+		y   *money.Money
+		add string
+		sub string
+		err error
+	}{
+		{x: money.MustParse("120.50", "CHF"), y: money.MustParse("0.125", "CHF"), add: "120.625", sub: "120.375"},
+		{x: money.MustParse("10", "CHF"), y: money.MustParse("2.5", "CHF"), add: "12.5", sub: "7.5"},
+		{x: money.MustParse("0.00", "CHF"), y: money.MustParse("0.0", "CHF"), add: "0.00", sub: "0.00"},
+		{x: money.MustParse("1.50", "CHF"), y: money.MustParse("0", "CHF"), add: "1.50", sub: "1.50"},
+		{x: money.MustParse("-1.50", "CHF"), y: money.MustParse("-2.25", "CHF"), add: "-3.75", sub: "0.75"},
+		{x: money.MustParse("1.50", "CHF"), y: money.MustParse("-1.5", "CHF"), add: "0.00", sub: "3.00"},
+		{x: money.MustParse("1.50", "CHF"), y: money.MustParse("1.50", "EUR"), err: money.ErrCurrencyMismatch},
+	}
+
+	for i, test := range table {
+		add, err := money.Add(test.x, test.y)
+		if test.err != err {
+			t.Errorf("#%d - expect error %v, but got %v", i, test.err, err)
+		}
+		sub, err := money.Sub(test.x, test.y)
+		if test.err != err {
+			t.Errorf("#%d - expect error %v, but got %v", i, test.err, err)
+		}
+		if test.err != nil {
+			continue
+		}
+
+		if expect := money.MustParse(test.add, "CHF"); !expect.EqualExact(add) {
+			t.Errorf("#%d - expect %s, but got %s %s", i, expect.Amount, add.Amount, add.Currency)
+		}
+		if expect := money.MustParse(test.sub, "CHF"); !expect.EqualExact(sub) {
+			t.Errorf("#%d - expect %s, but got %s %s", i, expect.Amount, sub.Amount, sub.Currency)
+		}
+	}
+}
+
+func TestMoney_ChangeFrom(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		price    *money.Money
+		tendered *money.Money
+		expect   string
+		err      error
+	}{
+		{price: money.MustParse("7.45", "CHF"), tendered: money.MustParse("7.45", "CHF"), expect: "0.00"},
+		{price: money.MustParse("7.45", "CHF"), tendered: money.MustParse("10.00", "CHF"), expect: "2.55"},
+		{price: money.MustParse("7.45", "CHF"), tendered: money.MustParse("50", "CHF"), expect: "42.55"},
+		{price: money.MustParse("7.45", "CHF"), tendered: money.MustParse("7.40", "CHF"), err: money.ErrInsufficientFunds},
+		{price: money.MustParse("7.45", "CHF"), tendered: money.MustParse("10.00", "EUR"), err: money.ErrCurrencyMismatch},
+	}
+
+	for i, test := range table {
+		res, err := test.price.ChangeFrom(test.tendered)
+		if test.err != err {
+			t.Errorf("#%d - expect error %v, but got %v", i, test.err, err)
+		}
+		if err != nil {
+			continue
+		}
+		if expect := money.MustParse(test.expect, "CHF"); !expect.EqualExact(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, expect.Amount, res.Amount)
+		}
+	}
+}
+
+func TestMoney_MinWith_MaxWith(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		x   *money.Money
+		y   *money.Money
+		min *money.Money
+		max *money.Money
+		err error
+	}{
+		{x: money.MustParse("12.50", "USD"), y: money.MustParse("9.99", "USD"), min: money.MustParse("9.99", "USD"), max: money.MustParse("12.50", "USD")},
+		{x: money.MustParse("-1", "USD"), y: money.MustParse("1", "USD"), min: money.MustParse("-1", "USD"), max: money.MustParse("1", "USD")},
+		// Equal amounts return x
+		{x: money.MustParse("10.00", "USD"), y: money.MustParse("10.0000", "USD"), min: money.MustParse("10.00", "USD"), max: money.MustParse("10.00", "USD")},
+		{x: money.MustParse("10.0000", "USD"), y: money.MustParse("10.00", "USD"), min: money.MustParse("10.0000", "USD"), max: money.MustParse("10.0000", "USD")},
+		{x: money.MustParse("10.00", "USD"), y: money.MustParse("10.00", "EUR"), err: money.ErrCurrencyMismatch},
+	}
+
+	for i, test := range table {
+		min, err := test.x.MinWith(test.y)
+		if test.err != err {
+			t.Errorf("#%d - expect error %v, but got %v", i, test.err, err)
+			continue
+		}
+		max, err := test.x.MaxWith(test.y)
+		if test.err != err {
+			t.Errorf("#%d - expect error %v, but got %v", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !test.min.EqualExact(min) {
+			t.Errorf("#%d - expect min %s, but got %s", i, test.min, min)
+		}
+		if !test.max.EqualExact(max) {
+			t.Errorf("#%d - expect max %s, but got %s", i, test.max, max)
+		}
+	}
+}
+
+func TestMoney_NonNegative(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  *money.Money
+		expect string
+	}{
+		{input: money.MustParse("-1.25", "USD"), expect: "0.00"},
+		{input: money.MustParse("-0.001", "USD"), expect: "0.00"},
+		{input: money.MustParse("-120", "JPY"), expect: "0"},
+		{input: money.MustParse("-0.00", "USD"), expect: "0.00"},
+		{input: money.MustParse("0", "USD"), expect: "0"},
+		{input: money.MustParse("1.25", "USD"), expect: "1.25"},
+		{input: money.MustParse("1.255", "USD"), expect: "1.255"},
+	}
+
+	for i, test := range table {
+		res := test.input.NonNegative()
+		if expect := money.MustParse(test.expect, test.input.Currency.String()); !expect.EqualExact(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, expect, res)
+		}
+		if res.Sign() == money.SignNegative {
+			t.Errorf("#%d - expect a non-negative amount, but got %s", i, res)
+		}
+	}
+}
+
+func TestMoney_ScaledAmount(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  *money.Money
+		expect string
+		err    error
+	}{
+		{input: money.MustParse("120.50", "USD"), expect: "12050"},
+		{input: money.MustParse("120.5", "USD"), expect: "12050"},
+		{input: money.MustParse("-0.01", "USD"), expect: "-1"},
+		{input: money.MustParse("120.5000", "USD"), expect: "12050"},
+		{input: money.MustParse("120", "JPY"), expect: "120"},
+		{input: money.MustParse("120.0", "JPY"), expect: "120"},
+		{input: money.MustParse("1.234", "BHD"), expect: "1234"},
+		{input: money.MustParse("120.505", "USD"), err: money.ErrNonIntegral},
+		{input: money.MustParse("120.5", "JPY"), err: money.ErrNonIntegral},
+	}
+
+	for i, test := range table {
+		scale := int32(test.input.Currency.Scale())
+		res, err := test.input.ScaledAmount(scale)
+		if test.err != err {
+			t.Errorf("#%d - expect error %s, but got %s", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if expect := money.MustParseDecimal(test.expect); !expect.Equal(res) || res.Exponent() != 0 {
+			t.Errorf("#%d - expect %s, but got %s", i, expect, res)
+		}
+	}
+}
+
+func TestMoney_Div(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  *money.Money
+		d      string
+		expect string
+	}{
+		{input: money.MustParse("10.00", "USD"), d: "3", expect: "3.333333"},
+		{input: money.MustParse("20.00", "USD"), d: "3", expect: "6.666667"},
+		{input: money.MustParse("-20.00", "USD"), d: "3", expect: "-6.666667"},
+		{input: money.MustParse("10.00", "USD"), d: "4", expect: "2.500000"},
+		{input: money.MustParse("100", "JPY"), d: "3", expect: "33.3333"},
+		{input: money.MustParse("1.000", "BHD"), d: "7", expect: "0.1428571"},
+	}
+
+	for i, test := range table {
+		res := test.input.Div(money.MustParseDecimal(test.d))
+		if test.expect != res.Amount.String() {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, res.Amount)
+		}
+		if scale := -res.Amount.Exponent(); scale != int32(test.input.Currency.Scale())+4 {
+			t.Errorf("#%d - expect scale %d, but got %d", i, test.input.Currency.Scale()+4, scale)
+		}
+		if res.Currency != test.input.Currency {
+			t.Errorf("#%d - expect currency %s, but got %s", i, test.input.Currency, res.Currency)
+		}
+	}
+}
+
+func TestMoney_DivRound(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input     *money.Money
+		d         string
+		precision int32
+		expect    string
+	}{
+		{input: money.MustParse("10.00", "USD"), d: "3", precision: 2, expect: "3.33"},
+		{input: money.MustParse("20.00", "USD"), d: "3", precision: 2, expect: "6.67"},
+		{input: money.MustParse("-20.00", "USD"), d: "3", precision: 2, expect: "-6.67"},
+		{input: money.MustParse("20.00", "USD"), d: "3", precision: 0, expect: "7"},
+		{input: money.MustParse("20.00", "USD"), d: "3", precision: 10, expect: "6.6666666667"},
+	}
+
+	for i, test := range table {
+		res := test.input.DivRound(money.MustParseDecimal(test.d), test.precision)
+		if expect := money.MustParseDecimal(test.expect); !expect.Equal(res.Amount) {
+			t.Errorf("#%d - expect %s, but got %s", i, expect, res.Amount)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package money
+
+// MoneyMap is a map indexed by Money values. Keys are compared like Equal, so
+// 120.00 CHF and 120.0000 CHF are the same key, whereas 120.00 EUR is another
+// one.
+//
+// The zero value is an empty map ready to use. It is not safe for concurrent
+// use.
+type MoneyMap[V any] struct {
+	m map[string]V
+}
+
+// NewMoneyMap returns an empty MoneyMap
+func NewMoneyMap[V any]() *MoneyMap[V] {
+	return &MoneyMap[V]{m: map[string]V{}}
+}
+
+// Set sets the value of key
+func (mm *MoneyMap[V]) Set(key *Money, value V) {
+	if mm.m == nil {
+		mm.m = map[string]V{}
+	}
+	mm.m[key.Key()] = value
+}
+
+// Get returns the value of key, and whether it exists
+func (mm *MoneyMap[V]) Get(key *Money) (V, bool) {
+	v, ok := mm.m[key.Key()]
+	return v, ok
+}
+
+// Delete removes key
+func (mm *MoneyMap[V]) Delete(key *Money) {
+	delete(mm.m, key.Key())
+}
+
+// Len returns the number of keys
+func (mm *MoneyMap[V]) Len() int {
+	return len(mm.m)
+}
@@ -0,0 +1,86 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/deixis/money"
+)
+
+func TestMoneyMap(t *testing.T) {
+	t.Parallel()
+
+	mm := money.NewMoneyMap[string]()
+	mm.Set(money.MustParse("120.00", "CHF"), "a")
+
+	table := []struct {
+		key    *money.Money
+		expect string
+		ok     bool
+	}{
+		{key: money.MustParse("120.00", "CHF"), expect: "a", ok: true},
+		{key: money.MustParse("120.0000", "CHF"), expect: "a", ok: true},
+		{key: money.MustParse("120", "CHF"), expect: "a", ok: true},
+		{key: money.MustParse("120", "EUR"), ok: false},
+		{key: money.MustParse("-120.00", "CHF"), ok: false},
+		{key: money.MustParse("12.00", "CHF"), ok: false},
+		{key: money.MustParse("1200", "CHF"), ok: false},
+	}
+
+	for i, test := range table {
+		res, ok := mm.Get(test.key)
+		if test.ok != ok || test.expect != res {
+			t.Errorf("#%d - expect %q %t, but got %q %t", i, test.expect, test.ok, res, ok)
+		}
+	}
+
+	mm.Set(money.MustParse("120.0", "CHF"), "b")
+	if mm.Len() != 1 {
+		t.Errorf("expect 1 entry, but got %d", mm.Len())
+	}
+	if res, _ := mm.Get(money.MustParse("120.00", "CHF")); res != "b" {
+		t.Errorf("expect b, but got %s", res)
+	}
+
+	mm.Delete(money.MustParse("120.000", "CHF"))
+	if _, ok := mm.Get(money.MustParse("120.00", "CHF")); ok {
+		t.Error("expect entry to be deleted")
+	}
+}
+
+func TestMoneyMap_Zero(t *testing.T) {
+	t.Parallel()
+
+	var mm money.MoneyMap[int]
+	if _, ok := mm.Get(money.MustParse("0.00", "USD")); ok {
+		t.Error("expect no entry")
+	}
+	mm.Set(money.MustParse("0.00", "USD"), 1)
+	mm.Set(money.MustParse("-0", "USD"), 2)
+	if res, ok := mm.Get(money.MustParse("0", "USD")); !ok || res != 2 {
+		t.Errorf("expect 2, but got %d %t", res, ok)
+	}
+	if mm.Len() != 1 {
+		t.Errorf("expect 1 entry, but got %d", mm.Len())
+	}
+}
+
+func TestMoney_Key(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  *money.Money
+		expect string
+	}{
+		{input: money.MustParse("120.00", "CHF"), expect: "120.0 CHF"},
+		{input: money.MustParse("120.0000", "CHF"), expect: "120.0 CHF"},
+		{input: money.MustParse("-120.50", "CHF"), expect: "-120.5 CHF"},
+		{input: money.MustParse("0.00", "EUR"), expect: "0.0 EUR"},
+		{input: money.MustParse("0.00000001", "EUR"), expect: "0.00000001 EUR"},
+	}
+
+	for i, test := range table {
+		if res := test.input.Key(); test.expect != res {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, res)
+		}
+	}
+}
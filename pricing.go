@@ -0,0 +1,80 @@
+package money
+
+import "errors"
+
+var (
+	// ErrInvalidTierSchedule indicates that a tier schedule is empty, does not
+	// start at the first unit, or its tiers are not in ascending order
+	ErrInvalidTierSchedule = errors.New("invalid tier schedule")
+	// ErrNegativeQuantity indicates that an operation was given a negative
+	// quantity
+	ErrNegativeQuantity = errors.New("negative quantity")
+)
+
+// Tier is a volume pricing tier. UnitPrice applies to every unit from MinQty
+// (counted from 1) up to the MinQty of the next tier.
+type Tier struct {
+	MinQty    int64
+	UnitPrice *Money
+}
+
+// TierSchedule is a list of pricing tiers in ascending MinQty order. The first
+// tier must start at the first unit (MinQty <= 1).
+//
+//   e.g. [{1, 10.00}, {11, 8.00}, {51, 5.00}]
+//        units 1-10 cost 10.00, units 11-50 cost 8.00, units 51+ cost 5.00
+type TierSchedule []Tier
+
+// Cost returns the total cost of qty units, where each unit is priced by the
+// tier it falls in.
+//
+//   e.g. [{1, 10.00}, {11, 8.00}, {51, 5.00}] 12	-> 10*10.00 + 2*8.00 = 116.00
+func (s TierSchedule) Cost(qty int64) (*Money, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	if qty < 0 {
+		return nil, ErrNegativeQuantity
+	}
+
+	c := s[0].UnitPrice.Currency
+	total := &Money{
+		Amount:   zero.rescale(-int32(c.Scale())),
+		Currency: c,
+	}
+	for i, t := range s {
+		from := t.MinQty
+		if from < 1 {
+			from = 1
+		}
+		if qty < from {
+			break
+		}
+		to := qty
+		if i+1 < len(s) && s[i+1].MinQty-1 < to {
+			to = s[i+1].MinQty - 1
+		}
+		total.Amount = total.Amount.Add(t.UnitPrice.MulInt(to - from + 1).Amount)
+	}
+	return total, nil
+}
+
+// validate checks that s is not empty, starts at the first unit, is in
+// strictly ascending order and has a single currency
+func (s TierSchedule) validate() error {
+	if len(s) == 0 || s[0].MinQty > 1 {
+		return ErrInvalidTierSchedule
+	}
+	for i, t := range s {
+		if t.UnitPrice == nil {
+			return ErrInvalidTierSchedule
+		}
+		if t.UnitPrice.Currency != s[0].UnitPrice.Currency {
+			return ErrCurrencyMismatch
+		}
+		if i > 0 && t.MinQty <= s[i-1].MinQty {
+			return ErrInvalidTierSchedule
+		}
+	}
+	return nil
+}
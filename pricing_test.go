@@ -0,0 +1,93 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/deixis/money"
+)
+
+func TestTierSchedule_Cost(t *testing.T) {
+	t.Parallel()
+
+	schedule := money.TierSchedule{
+		{MinQty: 1, UnitPrice: money.MustParse("10.00", "USD")},
+		{MinQty: 11, UnitPrice: money.MustParse("8.00", "USD")},
+		{MinQty: 51, UnitPrice: money.MustParse("5.00", "USD")},
+	}
+
+	table := []struct {
+		qty    int64
+		expect *money.Money
+	}{
+		{qty: 0, expect: money.MustParse("0.00", "USD")},
+		{qty: 1, expect: money.MustParse("10.00", "USD")},
+		{qty: 10, expect: money.MustParse("100.00", "USD")},
+		{qty: 11, expect: money.MustParse("108.00", "USD")},
+		{qty: 12, expect: money.MustParse("116.00", "USD")},
+		{qty: 50, expect: money.MustParse("420.00", "USD")},
+		{qty: 51, expect: money.MustParse("425.00", "USD")},
+		{qty: 100, expect: money.MustParse("670.00", "USD")},
+	}
+
+	for i, test := range table {
+		res, err := schedule.Cost(test.qty)
+		if err != nil {
+			t.Fatalf("#%d - %s", i, err)
+		}
+		if !test.expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect.Amount, res.Amount)
+		}
+	}
+}
+
+func TestTierSchedule_Cost_Invalid(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		schedule money.TierSchedule
+		qty      int64
+		err      error
+	}{
+		{
+			schedule: money.TierSchedule{},
+			qty:      1,
+			err:      money.ErrInvalidTierSchedule,
+		},
+		{
+			schedule: money.TierSchedule{
+				{MinQty: 5, UnitPrice: money.MustParse("10.00", "USD")},
+			},
+			qty: 1,
+			err: money.ErrInvalidTierSchedule,
+		},
+		{
+			schedule: money.TierSchedule{
+				{MinQty: 1, UnitPrice: money.MustParse("10.00", "USD")},
+				{MinQty: 1, UnitPrice: money.MustParse("8.00", "USD")},
+			},
+			qty: 1,
+			err: money.ErrInvalidTierSchedule,
+		},
+		{
+			schedule: money.TierSchedule{
+				{MinQty: 1, UnitPrice: money.MustParse("10.00", "USD")},
+				{MinQty: 11, UnitPrice: money.MustParse("8.00", "EUR")},
+			},
+			qty: 1,
+			err: money.ErrCurrencyMismatch,
+		},
+		{
+			schedule: money.TierSchedule{
+				{MinQty: 1, UnitPrice: money.MustParse("10.00", "USD")},
+			},
+			qty: -1,
+			err: money.ErrNegativeQuantity,
+		},
+	}
+
+	for i, test := range table {
+		if _, err := test.schedule.Cost(test.qty); err != test.err {
+			t.Errorf("#%d - expect error %s, but got %s", i, test.err, err)
+		}
+	}
+}
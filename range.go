@@ -0,0 +1,63 @@
+package money
+
+import "fmt"
+
+// Range is a closed interval of amounts in a single currency, such as a price
+// band. It must be built with NewRange.
+//
+//   e.g. [5.00, 10.00] USD
+type Range struct {
+	Min *Money
+	Max *Money
+}
+
+// NewRange returns the range [min, max]. It returns ErrCurrencyMismatch when
+// the currencies differ and ErrInvalidRange when min is greater than max.
+func NewRange(min, max *Money) (*Range, error) {
+	if min.Currency != max.Currency {
+		return nil, ErrCurrencyMismatch
+	}
+	if min.Amount.Cmp(max.Amount) > 0 {
+		return nil, ErrInvalidRange
+	}
+	return &Range{Min: min, Max: max}, nil
+}
+
+// Contains returns whether x is within r, bounds included. It returns
+// ErrCurrencyMismatch when the currencies differ.
+//
+//   e.g. [5.00, 10.00] USD 10.00 USD	-> true
+//   e.g. [5.00, 10.00] USD 10.01 USD	-> false
+func (r *Range) Contains(x *Money) (bool, error) {
+	if x.Currency != r.Min.Currency {
+		return false, ErrCurrencyMismatch
+	}
+	return x.Amount.Cmp(r.Min.Amount) >= 0 && x.Amount.Cmp(r.Max.Amount) <= 0, nil
+}
+
+// Clamp returns x limited to r. x is returned unchanged when it is within r.
+// It returns ErrCurrencyMismatch when the currencies differ.
+//
+// Unlike ClampToScale, the result is not rounded.
+//
+//   e.g. [5.00, 10.00] USD 4.999 USD	-> 5.00 USD
+//   e.g. [5.00, 10.00] USD 7.456 USD	-> 7.456 USD
+func (r *Range) Clamp(x *Money) (*Money, error) {
+	if x.Currency != r.Min.Currency {
+		return nil, ErrCurrencyMismatch
+	}
+	switch {
+	case x.Amount.Cmp(r.Min.Amount) < 0:
+		return r.Min, nil
+	case x.Amount.Cmp(r.Max.Amount) > 0:
+		return r.Max, nil
+	}
+	return x, nil
+}
+
+// String implements the fmt.Stringer interface
+//
+//   e.g. [5.00, 10.00] USD
+func (r *Range) String() string {
+	return fmt.Sprintf("[%s, %s] %s", r.Min.Amount, r.Max.Amount, r.Min.Currency)
+}
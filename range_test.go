@@ -0,0 +1,76 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/deixis/money"
+)
+
+func TestNewRange(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		min *money.Money
+		max *money.Money
+		err error
+	}{
+		{min: money.MustParse("5.00", "USD"), max: money.MustParse("10.00", "USD")},
+		{min: money.MustParse("5.00", "USD"), max: money.MustParse("5.0", "USD")},
+		{min: money.MustParse("10.00", "USD"), max: money.MustParse("5.00", "USD"), err: money.ErrInvalidRange},
+		{min: money.MustParse("5.00", "USD"), max: money.MustParse("10.00", "EUR"), err: money.ErrCurrencyMismatch},
+	}
+
+	for i, test := range table {
+		if _, err := money.NewRange(test.min, test.max); test.err != err {
+			t.Errorf("#%d - expect error %v, but got %v", i, test.err, err)
+		}
+	}
+}
+
+func TestRange(t *testing.T) {
+	t.Parallel()
+
+	r, err := money.NewRange(money.MustParse("5.00", "USD"), money.MustParse("10.00", "USD"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect, res := "[5.00, 10.00] USD", r.String(); expect != res {
+		t.Errorf("expect %s, but got %s", expect, res)
+	}
+
+	table := []struct {
+		input    *money.Money
+		contains bool
+		clamp    string
+		err      error
+	}{
+		{input: money.MustParse("4.999", "USD"), contains: false, clamp: "5.00"},
+		{input: money.MustParse("5.00", "USD"), contains: true, clamp: "5.00"},
+		{input: money.MustParse("7.456", "USD"), contains: true, clamp: "7.456"},
+		{input: money.MustParse("10.000", "USD"), contains: true, clamp: "10.00"},
+		{input: money.MustParse("10.001", "USD"), contains: false, clamp: "10.00"},
+		{input: money.MustParse("-7.00", "USD"), contains: false, clamp: "5.00"},
+		{input: money.MustParse("7.00", "EUR"), err: money.ErrCurrencyMismatch},
+	}
+
+	for i, test := range table {
+		contains, err := r.Contains(test.input)
+		if test.err != err {
+			t.Errorf("#%d - expect error %v, but got %v", i, test.err, err)
+		}
+		if test.contains != contains {
+			t.Errorf("#%d - expect contains %v, but got %v", i, test.contains, contains)
+		}
+
+		res, err := r.Clamp(test.input)
+		if test.err != err {
+			t.Errorf("#%d - expect error %v, but got %v", i, test.err, err)
+		}
+		if err != nil {
+			continue
+		}
+		if expect := money.MustParse(test.clamp, "USD"); !expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, expect.Amount, res.Amount)
+		}
+	}
+}
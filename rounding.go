@@ -1,9 +1,23 @@
 package money
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
 	"golang.org/x/text/currency"
 )
 
+var (
+	// ErrInvalidRoundingRule indicates that a rounding rule has a non-positive
+	// increment or an unknown rounding mode
+	ErrInvalidRoundingRule = errors.New("invalid rounding rule")
+	// ErrInvalidIncrement indicates that a rounding increment is not positive
+	// or finer than the currency scale
+	ErrInvalidIncrement = errors.New("invalid rounding increment")
+)
+
 // RoundingMode defines the rounding Mode to apply
 type RoundingMode string
 
@@ -17,6 +31,9 @@ const (
 	// ToNearest rounds to the nearest increment
 	// e.g. decimal: 1.45 increment: 0.1 result: 1.5
 	RoundToNearest RoundingMode = "to_nearest"
+	// HalfDown rounds to the nearest increment, ties toward zero
+	// e.g. decimal: 1.45 increment: 0.1 result: 1.4
+	RoundHalfDown RoundingMode = "half_down"
 )
 
 // RoundingKind defines a rounding standard for currencies
@@ -47,19 +64,154 @@ func (k RoundingKind) kind() currency.Kind {
 	return currency.Standard
 }
 
-// Round rounds the given amount from the given unit
+// Round rounds the given amount from the given unit. It panics on an unknown
+// mode, which would otherwise silently zero the amount.
 func Round(x Decimal, unit Decimal, mode RoundingMode) Decimal {
 	prec := unit.Exponent() * -1
 
 	switch mode {
 	case RoundDown:
 		rounded := x.RoundDown(prec)
-		return rounded.Sub(rounded.Mod(unit)).Truncate(prec)
+		switch m := rounded.Mod(unit); m.Sign() {
+		case SignPositive:
+			rounded = rounded.Sub(m)
+		case SignNegative:
+			rounded = rounded.Sub(unit.Add(m))
+		}
+		return rounded.Truncate(prec)
 	case RoundUp:
 		rounded := x.RoundUp(prec)
-		return rounded.Add(rounded.Mod(unit)).Truncate(prec)
+		switch m := rounded.Mod(unit); m.Sign() {
+		case SignPositive:
+			rounded = rounded.Add(unit.Sub(m))
+		case SignNegative:
+			rounded = rounded.Sub(m)
+		}
+		return rounded.Truncate(prec)
 	case RoundToNearest:
 		return x.RoundNearest(unit).Truncate(prec)
+	case RoundHalfDown:
+		return x.roundHalfDown(unit).Truncate(prec)
+	}
+	panic(fmt.Sprintf("money: unknown rounding mode %q", mode))
+}
+
+// DecimalContext is a rounding policy, which can be carried as a value and
+// applied consistently to many decimals.
+type DecimalContext struct {
+	// Precision is the number of decimal places to round to
+	Precision int32
+	// Mode is the rounding mode to apply
+	Mode RoundingMode
+}
+
+// Validate returns ErrInvalidRoundingRule when the context mode is unknown,
+// which includes the zero value
+func (ctx DecimalContext) Validate() error {
+	return validateMode(ctx.Mode)
+}
+
+// Round rounds d to the context precision with the context mode, like Round
+// with a unit of 10^-Precision. Like Round, it panics on an unknown mode, so
+// contexts built from configuration should be checked with Validate first.
+//
+//   e.g. {2, RoundUp} 1.231	-> 1.24
+func (ctx DecimalContext) Round(d Decimal) Decimal {
+	return Round(d, unitDecimal(-ctx.Precision), ctx.Mode)
+}
+
+// RoundWithAdjustment rounds x to the unit of the given kind with the given
+// mode, and returns the adjustment applied, such as rounded - x. The
+// adjustment has the sign of the change, so it can be recorded as a rounding
+// line. It returns ErrInvalidRoundingRule when mode is unknown.
+//
+//   e.g. 120.03 CHF cash to nearest	-> 120.05 CHF, 0.02 CHF
+//   e.g. 120.02 CHF cash to nearest	-> 120.00 CHF, -0.02 CHF
+func (x *Money) RoundWithAdjustment(kind RoundingKind, mode RoundingMode) (rounded *Money, adjustment *Money, err error) {
+	if err := validateMode(mode); err != nil {
+		return nil, nil, err
+	}
+	rounded = &Money{
+		Amount:   Round(x.Amount, x.Currency.RoundUnit(kind), mode),
+		Currency: x.Currency,
+	}
+	adjustment = &Money{
+		Amount:   rounded.Amount.Sub(x.Amount),
+		Currency: x.Currency,
+	}
+	return rounded, adjustment, nil
+}
+
+// RoundToIncrement rounds x to a multiple of increment with the given mode,
+// regardless of the currency cash unit. The increment must be positive and fit
+// within the currency scale.
+//
+//   e.g. 12.30 USD to nearest 0.50	-> 12.50 USD
+//   e.g. 12.30 USD up 1.00		-> 13.00 USD (e.g. then minus 0.01 for 12.99)
+func (x *Money) RoundToIncrement(increment Decimal, mode RoundingMode) (*Money, error) {
+	if increment.Sign() != SignPositive || !increment.FitsScale(int32(x.Currency.Scale())) {
+		return nil, ErrInvalidIncrement
+	}
+	if err := validateMode(mode); err != nil {
+		return nil, err
+	}
+	return &Money{
+		Amount:   Round(x.Amount, increment, mode),
+		Currency: x.Currency,
+	}, nil
+}
+
+// RoundingRule defines a rounding increment and mode mandated by a region,
+// regardless of the currency.
+type RoundingRule struct {
+	Increment Decimal
+	Mode      RoundingMode
+}
+
+func (r RoundingRule) validate() error {
+	if r.Increment.Sign() != SignPositive {
+		return ErrInvalidRoundingRule
+	}
+	return validateMode(r.Mode)
+}
+
+// validateMode returns ErrInvalidRoundingRule when mode is unknown
+func validateMode(mode RoundingMode) error {
+	switch mode {
+	case RoundDown, RoundUp, RoundToNearest, RoundHalfDown:
+		return nil
+	}
+	return ErrInvalidRoundingRule
+}
+
+var roundingRules = sync.Map{}
+
+// RegisterRoundingRule registers the rounding rule of a region, such as a
+// country code (e.g. CA).
+func RegisterRoundingRule(region string, rule RoundingRule) error {
+	if err := rule.validate(); err != nil {
+		return err
+	}
+	roundingRules.Store(strings.TrimSpace(strings.ToUpper(region)), rule)
+	return nil
+}
+
+// RoundByRule rounds m according to the rule registered for region. Regions
+// without a registered rule fall back to the currency cash rounding.
+func RoundByRule(m *Money, region string) (*Money, error) {
+	if err := m.Currency.Validate(); err != nil {
+		return nil, err
+	}
+
+	rule := RoundingRule{
+		Increment: m.Currency.RoundUnit(RoundingCash),
+		Mode:      RoundToNearest,
+	}
+	if v, ok := roundingRules.Load(strings.TrimSpace(strings.ToUpper(region))); ok {
+		rule = v.(RoundingRule)
 	}
-	return Decimal{}
+	return &Money{
+		Amount:   Round(m.Amount, rule.Increment, rule.Mode),
+		Currency: m.Currency,
+	}, nil
 }
@@ -160,3 +160,334 @@ func TestMoney_Round(t *testing.T) {
 		}
 	}
 }
+
+func TestRound_Modes(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input string
+		unit  string
+		down  string
+		up    string
+	}{
+		// Outputs that did not change
+		{input: "1.45", unit: "0.05", down: "1.45", up: "1.45"},
+		{input: "-1.45", unit: "0.05", down: "-1.45", up: "-1.45"},
+		{input: "1.41", unit: "0.1", down: "1.4", up: "1.5"},
+		{input: "120.5", unit: "1", down: "120", up: "121"},
+		// RoundUp used to add the remainder instead of the distance to the next
+		// increment
+		{input: "1.41", unit: "0.05", down: "1.40", up: "1.45"},  // up was 1.42
+		{input: "1.414", unit: "0.05", down: "1.40", up: "1.45"}, // up was 1.44
+		{input: "1.10", unit: "0.25", down: "1.00", up: "1.25"},  // up was 1.20
+		// Negative amounts used to move the wrong way
+		{input: "-1.41", unit: "0.05", down: "-1.45", up: "-1.40"},  // was -1.40, -1.42
+		{input: "-1.414", unit: "0.05", down: "-1.45", up: "-1.40"}, // was -1.40, -1.42
+		{input: "-1.10", unit: "0.25", down: "-1.25", up: "-1.00"},  // was -1.00, -1.20
+	}
+
+	for i, test := range table {
+		input := money.MustParseDecimal(test.input)
+		unit := money.MustParseDecimal(test.unit)
+
+		down := money.Round(input, unit, money.RoundDown)
+		if expect := money.MustParseDecimal(test.down); !expect.Equal(down) {
+			t.Errorf("#%d - expect down %s, but got %s", i, expect, down)
+		}
+		up := money.Round(input, unit, money.RoundUp)
+		if expect := money.MustParseDecimal(test.up); !expect.Equal(up) {
+			t.Errorf("#%d - expect up %s, but got %s", i, expect, up)
+		}
+	}
+}
+
+func TestRound_HalfDown(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input  string
+		unit   string
+		expect string
+	}{
+		{input: "0.125", unit: "0.01", expect: "0.12"},
+		{input: "-0.125", unit: "0.01", expect: "-0.12"},
+		{input: "1.025", unit: "0.05", expect: "1.00"},
+		{input: "-1.025", unit: "0.05", expect: "-1.00"},
+		{input: "1.0251", unit: "0.05", expect: "1.05"},
+		{input: "-1.0251", unit: "0.05", expect: "-1.05"},
+		{input: "1.075", unit: "0.05", expect: "1.05"},
+		{input: "0.125", unit: "0.25", expect: "0.00"},
+		{input: "0.375", unit: "0.25", expect: "0.25"},
+		{input: "120.5", unit: "1", expect: "120.0"},
+		{input: "-120.5", unit: "1", expect: "-120.0"},
+	}
+
+	for i, test := range table {
+		res := money.Round(money.MustParseDecimal(test.input), money.MustParseDecimal(test.unit), money.RoundHalfDown)
+		if test.expect != res.String() {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect, res)
+		}
+	}
+}
+
+func TestDecimalContext_Round(t *testing.T) {
+	t.Parallel()
+
+	inputs := []string{"1.231", "1.235", "-1.235", "-1.231", "1.2", "0"}
+	table := []struct {
+		ctx    money.DecimalContext
+		expect []string
+	}{
+		{
+			ctx:    money.DecimalContext{Precision: 2, Mode: money.RoundToNearest},
+			expect: []string{"1.23", "1.24", "-1.24", "-1.23", "1.20", "0.00"},
+		},
+		{
+			ctx:    money.DecimalContext{Precision: 2, Mode: money.RoundHalfDown},
+			expect: []string{"1.23", "1.23", "-1.23", "-1.23", "1.20", "0.00"},
+		},
+		{
+			ctx:    money.DecimalContext{Precision: 2, Mode: money.RoundUp},
+			expect: []string{"1.24", "1.24", "-1.23", "-1.23", "1.20", "0.00"},
+		},
+		{
+			ctx:    money.DecimalContext{Precision: 2, Mode: money.RoundDown},
+			expect: []string{"1.23", "1.23", "-1.24", "-1.24", "1.20", "0.00"},
+		},
+		{
+			ctx:    money.DecimalContext{Precision: 0, Mode: money.RoundUp},
+			expect: []string{"2", "2", "-1", "-1", "2", "0"},
+		},
+	}
+
+	for i, test := range table {
+		if err := test.ctx.Validate(); err != nil {
+			t.Fatalf("#%d - expect no error, but got %s", i, err)
+		}
+		for k, input := range inputs {
+			res := test.ctx.Round(money.MustParseDecimal(input))
+			if expect := money.MustParseDecimal(test.expect[k]); !expect.Equal(res) {
+				t.Errorf("#%d.%d - expect %s, but got %s - %s", i, k, expect, res, input)
+			}
+		}
+	}
+}
+
+func TestDecimalContext_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for i, ctx := range []money.DecimalContext{
+		{},
+		{Precision: 2},
+		{Precision: 2, Mode: "sideways"},
+	} {
+		if err := ctx.Validate(); err != money.ErrInvalidRoundingRule {
+			t.Errorf("#%d - expect error %s, but got %v", i, money.ErrInvalidRoundingRule, err)
+		}
+
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("#%d - expect Round to panic", i)
+				}
+			}()
+			res := ctx.Round(money.MustParseDecimal("1.239"))
+			t.Errorf("#%d - expect a panic, but got %s", i, res)
+		}()
+	}
+}
+
+func TestMoney_RoundWithAdjustment(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input      *money.Money
+		mode       money.RoundingMode
+		rounded    *money.Money
+		adjustment *money.Money
+	}{
+		{
+			input:      money.MustParse("120.03", "CHF"),
+			mode:       money.RoundToNearest,
+			rounded:    money.MustParse("120.05", "CHF"),
+			adjustment: money.MustParse("0.02", "CHF"),
+		},
+		{
+			input:      money.MustParse("120.02", "CHF"),
+			mode:       money.RoundToNearest,
+			rounded:    money.MustParse("120.00", "CHF"),
+			adjustment: money.MustParse("-0.02", "CHF"),
+		},
+		{
+			input:      money.MustParse("120.05", "CHF"),
+			mode:       money.RoundToNearest,
+			rounded:    money.MustParse("120.05", "CHF"),
+			adjustment: money.MustParse("0.00", "CHF"),
+		},
+		{
+			input:      money.MustParse("120.01", "CHF"),
+			mode:       money.RoundUp,
+			rounded:    money.MustParse("120.05", "CHF"),
+			adjustment: money.MustParse("0.04", "CHF"),
+		},
+		{
+			input:      money.MustParse("120.09", "CHF"),
+			mode:       money.RoundDown,
+			rounded:    money.MustParse("120.05", "CHF"),
+			adjustment: money.MustParse("-0.04", "CHF"),
+		},
+		{
+			input:      money.MustParse("-120.03", "CHF"),
+			mode:       money.RoundToNearest,
+			rounded:    money.MustParse("-120.05", "CHF"),
+			adjustment: money.MustParse("-0.02", "CHF"),
+		},
+	}
+
+	for i, test := range table {
+		rounded, adjustment, err := test.input.RoundWithAdjustment(money.RoundingCash, test.mode)
+		if err != nil {
+			t.Errorf("#%d - expect no error, but got %s", i, err)
+			continue
+		}
+		if !test.rounded.Equal(rounded) {
+			t.Errorf("#%d - expect %s, but got %s", i, test.rounded.Amount, rounded.Amount)
+		}
+		if !test.adjustment.Equal(adjustment) {
+			t.Errorf("#%d - expect adjustment %s, but got %s", i, test.adjustment.Amount, adjustment.Amount)
+		}
+	}
+
+	for i, mode := range []money.RoundingMode{"", "sideways"} {
+		_, _, err := money.MustParse("120.03", "CHF").RoundWithAdjustment(money.RoundingCash, mode)
+		if err != money.ErrInvalidRoundingRule {
+			t.Errorf("#%d - expect error %s, but got %v", i, money.ErrInvalidRoundingRule, err)
+		}
+	}
+}
+
+func TestMoney_RoundToIncrement(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		input     *money.Money
+		increment string
+		mode      money.RoundingMode
+		expect    *money.Money
+		err       error
+	}{
+		{input: money.MustParse("12.30", "USD"), increment: "0.50", mode: money.RoundToNearest, expect: money.MustParse("12.50", "USD")},
+		{input: money.MustParse("12.20", "USD"), increment: "0.50", mode: money.RoundToNearest, expect: money.MustParse("12.00", "USD")},
+		{input: money.MustParse("12.75", "USD"), increment: "0.50", mode: money.RoundToNearest, expect: money.MustParse("13.00", "USD")},
+		{input: money.MustParse("12.745", "USD"), increment: "0.50", mode: money.RoundToNearest, expect: money.MustParse("12.50", "USD")},
+		{input: money.MustParse("12.01", "USD"), increment: "0.50", mode: money.RoundUp, expect: money.MustParse("12.50", "USD")},
+		{input: money.MustParse("12.49", "USD"), increment: "0.50", mode: money.RoundDown, expect: money.MustParse("12.00", "USD")},
+		{input: money.MustParse("12.30", "USD"), increment: "1", mode: money.RoundUp, expect: money.MustParse("13.00", "USD")},
+		{input: money.MustParse("1234", "JPY"), increment: "100", mode: money.RoundToNearest, expect: money.MustParse("1200", "JPY")},
+		{input: money.MustParse("12.30", "USD"), increment: "0", mode: money.RoundUp, err: money.ErrInvalidIncrement},
+		{input: money.MustParse("12.30", "USD"), increment: "-0.50", mode: money.RoundUp, err: money.ErrInvalidIncrement},
+		{input: money.MustParse("12.30", "USD"), increment: "0.005", mode: money.RoundUp, err: money.ErrInvalidIncrement},
+		{input: money.MustParse("1234", "JPY"), increment: "0.5", mode: money.RoundUp, err: money.ErrInvalidIncrement},
+		{input: money.MustParse("12.30", "USD"), increment: "0.50", mode: "sideways", err: money.ErrInvalidRoundingRule},
+	}
+
+	for i, test := range table {
+		res, err := test.input.RoundToIncrement(money.MustParseDecimal(test.increment), test.mode)
+		if test.err != err {
+			t.Errorf("#%d - expect error %s, but got %s", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !test.expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect.Amount, res.Amount)
+		}
+	}
+}
+
+func TestMoney_RoundToIncrement_Charm(t *testing.T) {
+	t.Parallel()
+
+	cent := money.MustParse("0.01", "USD")
+	table := []struct {
+		input  *money.Money
+		expect *money.Money
+	}{
+		{input: money.MustParse("12.30", "USD"), expect: money.MustParse("12.99", "USD")},
+		{input: money.MustParse("12.99", "USD"), expect: money.MustParse("12.99", "USD")},
+		{input: money.MustParse("13.00", "USD"), expect: money.MustParse("13.99", "USD")},
+	}
+
+	for i, test := range table {
+		// Charm pricing ending in .99 rounds up the next cent to a whole unit
+		next := &money.Money{Amount: test.input.Amount.Add(cent.Amount), Currency: test.input.Currency}
+		res, err := next.RoundToIncrement(money.MustParseDecimal("1"), money.RoundUp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Amount = res.Amount.Sub(cent.Amount)
+		if !test.expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect.Amount, res.Amount)
+		}
+	}
+}
+
+func TestRoundByRule(t *testing.T) {
+	t.Parallel()
+
+	rules := map[string]money.RoundingRule{
+		"CA": {Increment: money.MustParseDecimal("0.05"), Mode: money.RoundToNearest},
+		"XQ": {Increment: money.MustParseDecimal("0.25"), Mode: money.RoundToNearest},
+		"XR": {Increment: money.MustParseDecimal("0.10"), Mode: money.RoundDown},
+	}
+	for region, rule := range rules {
+		if err := money.RegisterRoundingRule(region, rule); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	table := []struct {
+		input  *money.Money
+		region string
+		expect *money.Money
+	}{
+		{input: money.MustParse("1.02", "CAD"), region: "CA", expect: money.MustParse("1.00", "CAD")},
+		{input: money.MustParse("1.03", "CAD"), region: "ca", expect: money.MustParse("1.05", "CAD")},
+		{input: money.MustParse("1.12", "USD"), region: "XQ", expect: money.MustParse("1.00", "USD")},
+		{input: money.MustParse("1.13", "USD"), region: "XQ", expect: money.MustParse("1.25", "USD")},
+		{input: money.MustParse("1.38", "USD"), region: "XQ", expect: money.MustParse("1.50", "USD")},
+		{input: money.MustParse("1.19", "EUR"), region: "XR", expect: money.MustParse("1.10", "EUR")},
+		// Fall back to the currency cash rounding
+		{input: money.MustParse("1.03", "CHF"), region: "CH", expect: money.MustParse("1.05", "CHF")},
+		{input: money.MustParse("1.03", "USD"), region: "", expect: money.MustParse("1.03", "USD")},
+	}
+
+	for i, test := range table {
+		res, err := money.RoundByRule(test.input, test.region)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !test.expect.Equal(res) {
+			t.Errorf("#%d - expect %s, but got %s", i, test.expect.Amount, res.Amount)
+		}
+	}
+}
+
+func TestRegisterRoundingRule_Invalid(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		rule money.RoundingRule
+	}{
+		{rule: money.RoundingRule{Increment: money.MustParseDecimal("0"), Mode: money.RoundToNearest}},
+		{rule: money.RoundingRule{Increment: money.MustParseDecimal("-0.05"), Mode: money.RoundToNearest}},
+		{rule: money.RoundingRule{Increment: money.MustParseDecimal("0.05"), Mode: "sideways"}},
+	}
+
+	for i, test := range table {
+		if err := money.RegisterRoundingRule("XS", test.rule); err != money.ErrInvalidRoundingRule {
+			t.Errorf("#%d - expect error %s, but got %s", i, money.ErrInvalidRoundingRule, err)
+		}
+	}
+}